@@ -0,0 +1,341 @@
+package typesense
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBatchIndexerSize          = 100
+	defaultBatchIndexerFlushInterval = time.Second
+)
+
+// ErrBatchIndexerClosed returned when an operation is added to a
+// BatchIndexer that was already closed.
+var ErrBatchIndexerClosed = errors.New("batch indexer is closed")
+
+// BatchIndexerConfig is the configuration of a BatchIndexer.
+type BatchIndexerConfig struct {
+	// Action is the import action used for added documents.
+	// Default value is upsert.
+	Action string
+
+	// BatchSize is the number of buffered operations that triggers
+	// a flush. Default value is 100.
+	BatchSize int
+
+	// FlushInterval is the maximum time an operation stays buffered
+	// before being flushed. Default value is one second.
+	FlushInterval time.Duration
+
+	// Workers is the maximum number of concurrent flushes.
+	// Default value is 1.
+	Workers int
+
+	// MaxRetries is the maximum number of times a document that failed
+	// with a retryable error is imported again. Default value is 0.
+	MaxRetries int
+
+	// DeadLetter receives every document that could not be imported.
+	DeadLetter DeadLetterSink
+
+	// Progress receives the progress of the indexer after every flush.
+	Progress ProgressObserver
+
+	// ChangeDetector skips the documents that did not change since their
+	// last successful import, the hashes of deleted documents are
+	// removed. Default value is the detector of the client, see
+	// EnableChangeDetection.
+	ChangeDetector *ChangeDetector
+
+	// OnError is called with a *BatchError for every operation that
+	// failed to be flushed. It may be called concurrently by the
+	// flush workers.
+	OnError func(err error)
+}
+
+// BatchError is the error reported for an operation of a BatchIndexer
+// that failed.
+type BatchError struct {
+	// Document is the document that failed to be indexed.
+	Document interface{}
+
+	// DocumentID is the id of the document that failed to be deleted,
+	// it is empty when an index failed.
+	DocumentID string
+
+	// Err is the cause of the failure.
+	Err error
+}
+
+func (e *BatchError) Error() string {
+	if e.DocumentID != "" {
+		return "delete of document " + e.DocumentID + " failed: " + e.Err.Error()
+	}
+	return "index of document failed: " + e.Err.Error()
+}
+
+// Unwrap returns the cause of the failure.
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// batchOperation is a buffered document to index or document id
+// to delete.
+type batchOperation struct {
+	delete     bool
+	document   interface{}
+	documentID string
+}
+
+// BatchIndexer buffers documents to index and delete in a collection
+// and flushes them through the import endpoint once BatchSize
+// operations are buffered or FlushInterval elapses. It is safe for
+// concurrent use. Operations are applied in order within a flush, but
+// flushes may run concurrently when more than one worker is used.
+type BatchIndexer struct {
+	client         *Client
+	collectionName string
+	config         BatchIndexerConfig
+	tracker        *progressTracker
+
+	mu         sync.Mutex
+	operations []batchOperation
+	closed     bool
+	pending    sync.WaitGroup
+
+	batches chan []batchOperation
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewBatchIndexer creates a BatchIndexer for the collection and starts
+// its flush workers. Close must be called to flush the remaining
+// operations and release the workers.
+func (c *Client) NewBatchIndexer(collectionName string, config BatchIndexerConfig) *BatchIndexer {
+	if config.Action == "" {
+		config.Action = ImportActionUpsert
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultBatchIndexerSize
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = defaultBatchIndexerFlushInterval
+	}
+	if config.Workers <= 0 {
+		config.Workers = 1
+	}
+	b := &BatchIndexer{
+		client:         c,
+		collectionName: collectionName,
+		config:         config,
+		tracker:        newProgressTracker(config.Progress, 0),
+		batches:        make(chan []batchOperation, config.Workers),
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+	var workers sync.WaitGroup
+	workers.Add(config.Workers)
+	for i := 0; i < config.Workers; i++ {
+		go func() {
+			defer workers.Done()
+			for batch := range b.batches {
+				b.flush(batch)
+			}
+		}()
+	}
+	go func() {
+		workers.Wait()
+		close(b.done)
+	}()
+	go b.tick()
+	return b
+}
+
+// Add buffers a document to be indexed. When the buffer is full it is
+// sent to the flush workers, Add blocks until a worker is free if every
+// worker is busy and Workers batches are already queued.
+func (b *BatchIndexer) Add(document interface{}) error {
+	return b.enqueue(batchOperation{document: document})
+}
+
+// Delete buffers the deletion of a document by its id, it blocks as
+// Add does.
+func (b *BatchIndexer) Delete(documentID string) error {
+	return b.enqueue(batchOperation{delete: true, documentID: documentID})
+}
+
+// Flush sends the buffered operations to the flush workers without
+// waiting for them to be applied. It blocks until a worker is free if
+// every worker is busy and Workers batches are already queued.
+func (b *BatchIndexer) Flush() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrBatchIndexerClosed
+	}
+	batch := b.take()
+	b.mu.Unlock()
+	b.send(batch)
+	return nil
+}
+
+// Close flushes the buffered operations and waits for the workers to
+// apply them. It returns the context error if the context is done
+// before every operation was applied, without waiting for busy workers,
+// the operations are then still applied in the background.
+func (b *BatchIndexer) Close(ctx context.Context) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrBatchIndexerClosed
+	}
+	b.closed = true
+	batch := b.take()
+	b.mu.Unlock()
+	close(b.stop)
+	go func() {
+		b.send(batch)
+		b.pending.Wait()
+		close(b.batches)
+	}()
+	select {
+	case <-b.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *BatchIndexer) enqueue(operation batchOperation) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrBatchIndexerClosed
+	}
+	b.operations = append(b.operations, operation)
+	if len(b.operations) < b.config.BatchSize {
+		b.mu.Unlock()
+		return nil
+	}
+	batch := b.take()
+	b.mu.Unlock()
+	b.send(batch)
+	return nil
+}
+
+// take removes the buffered operations, registering them as pending
+// so Close waits for them to be sent. It must be called with mu held.
+func (b *BatchIndexer) take() []batchOperation {
+	if len(b.operations) == 0 {
+		return nil
+	}
+	batch := b.operations
+	b.operations = nil
+	b.pending.Add(1)
+	return batch
+}
+
+func (b *BatchIndexer) send(batch []batchOperation) {
+	if batch == nil {
+		return
+	}
+	b.batches <- batch
+	b.pending.Done()
+}
+
+func (b *BatchIndexer) tick() {
+	ticker := time.NewTicker(b.config.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			b.mu.Lock()
+			if b.closed {
+				b.mu.Unlock()
+				return
+			}
+			batch := b.take()
+			b.mu.Unlock()
+			b.send(batch)
+		case <-b.stop:
+			return
+		}
+	}
+}
+
+// flush applies the batch, grouping consecutive operations of the same
+// kind so documents are imported and deleted in the order they were
+// added.
+func (b *BatchIndexer) flush(batch []batchOperation) {
+	for start := 0; start < len(batch); {
+		isDelete := batch[start].delete
+		end := start + 1
+		for end < len(batch) && batch[end].delete == isDelete {
+			end++
+		}
+		if isDelete {
+			b.flushDeletes(batch[start:end])
+		} else {
+			b.flushDocuments(batch[start:end])
+		}
+		start = end
+	}
+}
+
+func (b *BatchIndexer) flushDocuments(operations []batchOperation) {
+	documents := make([]interface{}, len(operations))
+	for i, operation := range operations {
+		documents[i] = operation.document
+	}
+	report, err := b.client.importDocuments(b.collectionName, documents, &ImportOptions{
+		Action:         b.config.Action,
+		BatchSize:      len(documents),
+		MaxRetries:     b.config.MaxRetries,
+		DeadLetter:     b.config.DeadLetter,
+		ChangeDetector: b.config.ChangeDetector,
+	}, b.tracker)
+	if err != nil {
+		for _, document := range documents[len(report.Results):] {
+			b.report(&BatchError{Document: document, Err: err})
+		}
+	}
+	for i, result := range report.Results {
+		if result.Success {
+			continue
+		}
+		if result.err != nil {
+			b.report(&BatchError{Document: documents[i], Err: result.err})
+		} else {
+			b.report(&BatchError{Document: documents[i], Err: APIError{Message: result.Error}})
+		}
+	}
+}
+
+func (b *BatchIndexer) flushDeletes(operations []batchOperation) {
+	ids := make([]string, len(operations))
+	for i, operation := range operations {
+		ids[i] = operation.documentID
+	}
+	if _, err := b.client.DeleteDocuments(b.collectionName, idsFilter(ids)); err != nil {
+		for _, id := range ids {
+			b.report(&BatchError{DocumentID: id, Err: err})
+		}
+		return
+	}
+	if detector := b.client.currentChangeDetector(b.config.ChangeDetector); detector != nil {
+		for _, id := range ids {
+			if err := detector.forget(b.collectionName, id); err != nil {
+				b.report(&BatchError{DocumentID: id, Err: err})
+			}
+		}
+	}
+}
+
+func (b *BatchIndexer) report(err error) {
+	if b.config.OnError != nil {
+		b.config.OnError(err)
+	}
+}
@@ -0,0 +1,177 @@
+package typesense
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBatchIndexer(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		imported int
+		deleted  []string
+	)
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if req.Method == http.MethodDelete {
+			deleted = append(deleted, req.URL.Query().Get("filter_by"))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(`{"num_deleted": 1}`)),
+			}, nil
+		}
+		var lines []string
+		scanner := bufio.NewScanner(req.Body)
+		for scanner.Scan() {
+			imported++
+			lines = append(lines, `{"success": true}`)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(strings.Join(lines, "\n"))),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	indexer := client.NewBatchIndexer(collectionNameTest, BatchIndexerConfig{
+		BatchSize:     10,
+		FlushInterval: time.Hour,
+		Workers:       2,
+		OnError: func(err error) {
+			t.Errorf("Expected to receive no errors, received %v", err)
+		},
+	})
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 7; j++ {
+				if err := indexer.Add(testDocument); err != nil {
+					t.Errorf("Expected to receive no errors, received %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if err := indexer.Delete("a,b"); err != nil {
+		t.Errorf("Expected to receive no errors, received %v", err)
+	}
+	if err := indexer.Close(context.Background()); err != nil {
+		t.Errorf("Expected to receive no errors, received %v", err)
+	}
+	if imported != 35 {
+		t.Errorf("Expected to import %d documents, imported %d", 35, imported)
+	}
+	if len(deleted) != 1 || deleted[0] != "id:[`a,b`]" {
+		t.Errorf("Expected to delete with filter %q, received %v", "id:[`a,b`]", deleted)
+	}
+	if err := indexer.Add(testDocument); err != ErrBatchIndexerClosed {
+		t.Errorf("Expected to receive error %v, received %v", ErrBatchIndexerClosed, err)
+	}
+}
+
+func TestBatchIndexer_flushInterval(t *testing.T) {
+	flushed := make(chan struct{}, 1)
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		flushed <- struct{}{}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"success": true}`)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	indexer := client.NewBatchIndexer(collectionNameTest, BatchIndexerConfig{
+		FlushInterval: 10 * time.Millisecond,
+	})
+	defer indexer.Close(context.Background())
+	if err := indexer.Add(testDocument); err != nil {
+		t.Errorf("Expected to receive no errors, received %v", err)
+	}
+	select {
+	case <-flushed:
+	case <-time.After(time.Second):
+		t.Errorf("Expected the document to be flushed after the flush interval")
+	}
+}
+
+func TestBatchIndexer_onError(t *testing.T) {
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: ioutil.NopCloser(strings.NewReader(
+				`{"success": false, "error": "bad document"}` + "\n" + `{"success": true}`,
+			)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	var errs []error
+	indexer := client.NewBatchIndexer(collectionNameTest, BatchIndexerConfig{
+		OnError: func(err error) {
+			errs = append(errs, err)
+		},
+	})
+	indexer.Add(testDocumentStruct{Field1: "invalid"})
+	indexer.Add(testDocument)
+	if err := indexer.Close(context.Background()); err != nil {
+		t.Errorf("Expected to receive no errors, received %v", err)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("Expected to receive 1 error, received %d", len(errs))
+	}
+	var batchErr *BatchError
+	if !errors.As(errs[0], &batchErr) || batchErr.Document != (testDocumentStruct{Field1: "invalid"}) {
+		t.Errorf("Expected a batch error for the invalid document, received %v", errs[0])
+	}
+}
+
+func TestBatchIndexer_closeDeadline(t *testing.T) {
+	release := make(chan struct{})
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		<-release
+		var lines []string
+		scanner := bufio.NewScanner(req.Body)
+		for scanner.Scan() {
+			lines = append(lines, `{"success": true}`)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(strings.Join(lines, "\n"))),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	indexer := client.NewBatchIndexer(collectionNameTest, BatchIndexerConfig{BatchSize: 2})
+	for i := 0; i < 5; i++ {
+		indexer.Add(testDocument)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := indexer.Close(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected to receive error %v, received %v", context.DeadlineExceeded, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected Close to return at its deadline, returned after %v", elapsed)
+	}
+	close(release)
+	<-indexer.done
+}
@@ -0,0 +1,300 @@
+package typesense
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// HashStore stores the content hash of the last successful write of
+// every document. Implementations must be safe for concurrent use.
+type HashStore interface {
+	// GetHash returns the hash of the document, the boolean is false
+	// if there is no hash for the document.
+	GetHash(collectionName, documentID string) (string, bool, error)
+
+	// SetHash stores the hash of the document.
+	SetHash(collectionName, documentID, hash string) error
+
+	// DeleteHash removes the hash of the document, it is not an error
+	// if there is no hash for the document.
+	DeleteHash(collectionName, documentID string) error
+}
+
+// ChangeDetector detects documents that did not change since their
+// last successful write, comparing the SHA-256 hash of their canonical
+// JSON encoding with the hash kept in a HashStore. Documents without an
+// id are always considered changed. The hash of a document is removed
+// when it is deleted by id, documents deleted by a filter or with their
+// collection keep their hash, so the store must be cleared by the
+// caller after such deletes.
+type ChangeDetector struct {
+	store HashStore
+}
+
+// NewChangeDetector creates a ChangeDetector that keeps the hashes in
+// the store.
+func NewChangeDetector(store HashStore) *ChangeDetector {
+	return &ChangeDetector{store: store}
+}
+
+// changed reports whether the JSON encoded document changed since its
+// last write, returning its id and hash to be recorded once written.
+func (d *ChangeDetector) changed(collectionName string, document []byte) (id, hash string, changed bool, err error) {
+	id, ok := lineDocumentID(document)
+	if !ok {
+		return "", "", true, nil
+	}
+	hash, err = canonicalHash(document)
+	if err != nil {
+		return "", "", true, err
+	}
+	lastHash, ok, err := d.store.GetHash(collectionName, id)
+	if err != nil {
+		return "", "", true, err
+	}
+	return id, hash, !ok || lastHash != hash, nil
+}
+
+// record stores the hash of a document that was successfully written.
+func (d *ChangeDetector) record(collectionName, id, hash string) error {
+	if id == "" {
+		return nil
+	}
+	return d.store.SetHash(collectionName, id, hash)
+}
+
+// forget removes the hashes of the deleted documents.
+func (d *ChangeDetector) forget(collectionName string, ids ...string) error {
+	for _, id := range ids {
+		if err := d.store.DeleteHash(collectionName, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EnableChangeDetection makes IndexDocument and UpsertDocument skip the
+// documents that did not change since their last successful write, and
+// the imports skip them when their options have no ChangeDetector.
+// DeleteDocument removes the hash of the deleted document. Skipped
+// documents are not sent, their DocumentResponse has the document as
+// Data.
+func (c *Client) EnableChangeDetection(detector *ChangeDetector) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.changeDetector = detector
+}
+
+// DisableChangeDetection stops skipping unchanged documents on the
+// writes that have no ChangeDetector of their own.
+func (c *Client) DisableChangeDetection() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.changeDetector = nil
+}
+
+// currentChangeDetector returns the detector if it is not nil, or the
+// detector of the client.
+func (c *Client) currentChangeDetector(detector *ChangeDetector) *ChangeDetector {
+	if detector != nil {
+		return detector
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.changeDetector
+}
+
+// canonicalHash returns the hex encoded SHA-256 hash of the canonical
+// JSON encoding of the document, which has its object keys sorted.
+func canonicalHash(document []byte) (string, error) {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return "", err
+	}
+	canonical, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// MemoryHashStore is a HashStore that keeps the hashes in memory.
+type MemoryHashStore struct {
+	mu     sync.RWMutex
+	hashes map[string]string
+}
+
+// NewMemoryHashStore creates an empty MemoryHashStore.
+func NewMemoryHashStore() *MemoryHashStore {
+	return &MemoryHashStore{hashes: make(map[string]string)}
+}
+
+// GetHash returns the hash of the document.
+func (s *MemoryHashStore) GetHash(collectionName, documentID string) (string, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	hash, ok := s.hashes[hashStoreKey(collectionName, documentID)]
+	return hash, ok, nil
+}
+
+// SetHash stores the hash of the document.
+func (s *MemoryHashStore) SetHash(collectionName, documentID, hash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hashes[hashStoreKey(collectionName, documentID)] = hash
+	return nil
+}
+
+// DeleteHash removes the hash of the document.
+func (s *MemoryHashStore) DeleteHash(collectionName, documentID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.hashes, hashStoreKey(collectionName, documentID))
+	return nil
+}
+
+func hashStoreKey(collectionName, documentID string) string {
+	return collectionName + "\x00" + documentID
+}
+
+// hashStoreEntry is a line of the file of a FileHashStore, an entry
+// without a hash removes the hash of the document.
+type hashStoreEntry struct {
+	Collection string `json:"collection"`
+	ID         string `json:"id"`
+	Hash       string `json:"hash"`
+}
+
+// FileHashStore is a HashStore that keeps the hashes in memory and
+// persists them to a file as JSON lines, so they survive restarts.
+// New hashes are appended to the file and the file is compacted when
+// the store is opened. Close must be called to flush the file.
+type FileHashStore struct {
+	*MemoryHashStore
+
+	mu     sync.Mutex
+	file   *os.File
+	writer *bufio.Writer
+}
+
+// NewFileHashStore opens the FileHashStore persisted at path, creating
+// the file if it does not exist.
+func NewFileHashStore(path string) (*FileHashStore, error) {
+	store := FileHashStore{MemoryHashStore: NewMemoryHashStore()}
+	entries := make(map[string]hashStoreEntry)
+	if file, err := os.Open(path); err == nil {
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			var entry hashStoreEntry
+			if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+				continue
+			}
+			key := hashStoreKey(entry.Collection, entry.ID)
+			if entry.Hash == "" {
+				delete(entries, key)
+				delete(store.hashes, key)
+				continue
+			}
+			entries[key] = entry
+			store.hashes[key] = entry.Hash
+		}
+		file.Close()
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return nil, err
+	}
+	writer := bufio.NewWriter(tmp)
+	encoder := json.NewEncoder(writer)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+			return nil, err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return nil, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	store.file = file
+	store.writer = bufio.NewWriter(file)
+	return &store, nil
+}
+
+// SetHash stores the hash of the document and appends it to the file.
+func (s *FileHashStore) SetHash(collectionName, documentID, hash string) error {
+	line, err := json.Marshal(hashStoreEntry{Collection: collectionName, ID: documentID, Hash: hash})
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.writer.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return s.MemoryHashStore.SetHash(collectionName, documentID, hash)
+}
+
+// DeleteHash removes the hash of the document and appends the removal
+// to the file.
+func (s *FileHashStore) DeleteHash(collectionName, documentID string) error {
+	line, err := json.Marshal(hashStoreEntry{Collection: collectionName, ID: documentID})
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.writer.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return s.MemoryHashStore.DeleteHash(collectionName, documentID)
+}
+
+// Flush writes the buffered hashes to the file.
+func (s *FileHashStore) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.writer.Flush()
+}
+
+// Close flushes the buffered hashes and closes the file.
+func (s *FileHashStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.writer.Flush(); err != nil {
+		s.file.Close()
+		return err
+	}
+	return s.file.Close()
+}
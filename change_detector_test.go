@@ -0,0 +1,240 @@
+package typesense
+
+import (
+	"bufio"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestImportDocuments_changeDetector(t *testing.T) {
+	var sent int
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		var lines []string
+		scanner := bufio.NewScanner(req.Body)
+		for scanner.Scan() {
+			sent++
+			lines = append(lines, `{"success": true}`)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(strings.Join(lines, "\n"))),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	opts := ImportOptions{
+		Action:         ImportActionUpsert,
+		ChangeDetector: NewChangeDetector(NewMemoryHashStore()),
+	}
+	documents := []interface{}{
+		map[string]interface{}{"id": "1", "name": "a", "tags": []string{"x"}},
+		map[string]interface{}{"id": "2", "name": "b"},
+		map[string]interface{}{"name": "no id"},
+	}
+	report, err := client.ImportDocuments(collectionNameTest, documents, &opts)
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if report.NumImported != 3 || report.NumSkipped != 0 {
+		t.Errorf("Expected 3 imported documents, received %+v", report)
+	}
+	documents[1] = map[string]interface{}{"id": "2", "name": "changed"}
+	report, err = client.ImportDocuments(collectionNameTest, documents, &opts)
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if report.NumImported != 2 || report.NumSkipped != 1 || !report.Results[0].Skipped {
+		t.Errorf("Expected 2 imported and 1 skipped documents, received %+v", report)
+	}
+	if sent != 5 {
+		t.Errorf("Expected to send %d documents, sent %d", 5, sent)
+	}
+}
+
+func TestCanonicalHash(t *testing.T) {
+	a, _ := canonicalHash([]byte(`{"id": "1", "b": 1.50, "a": [1, 2]}`))
+	b, _ := canonicalHash([]byte(`{"a":[1,2],"b":1.50,"id":"1"}`))
+	c, _ := canonicalHash([]byte(`{"a":[2,1],"b":1.50,"id":"1"}`))
+	if a != b {
+		t.Errorf("Expected documents with different key order to have the same hash")
+	}
+	if a == c {
+		t.Errorf("Expected documents with different values to have different hashes")
+	}
+}
+
+func TestFileHashStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hashes.jsonl")
+	store, err := NewFileHashStore(path)
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	store.SetHash("books", "1", "first")
+	store.SetHash("books", "1", "second")
+	store.SetHash("authors", "1", "author")
+	if err := store.Close(); err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	store, err = NewFileHashStore(path)
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	defer store.Close()
+	if hash, ok, _ := store.GetHash("books", "1"); !ok || hash != "second" {
+		t.Errorf("Expected hash %q, received %q", "second", hash)
+	}
+	if hash, ok, _ := store.GetHash("authors", "1"); !ok || hash != "author" {
+		t.Errorf("Expected hash %q, received %q", "author", hash)
+	}
+	data, _ := ioutil.ReadFile(path)
+	if lines := strings.Count(string(data), "\n"); lines != 2 {
+		t.Errorf("Expected the file to be compacted to %d lines, received %d", 2, lines)
+	}
+}
+
+func TestFileHashStore_deleteHash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hashes.jsonl")
+	store, err := NewFileHashStore(path)
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	store.SetHash("books", "1", "first")
+	store.SetHash("books", "2", "second")
+	if err := store.DeleteHash("books", "1"); err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if _, ok, _ := store.GetHash("books", "1"); ok {
+		t.Errorf("Expected the hash of the deleted document to be removed")
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	store, err = NewFileHashStore(path)
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	defer store.Close()
+	if _, ok, _ := store.GetHash("books", "1"); ok {
+		t.Errorf("Expected the hash of the deleted document to stay removed")
+	}
+	if hash, ok, _ := store.GetHash("books", "2"); !ok || hash != "second" {
+		t.Errorf("Expected hash %q, received %q", "second", hash)
+	}
+}
+
+func TestBatchIndexer_changeDetectorDelete(t *testing.T) {
+	var sent int
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodDelete {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(`{"num_deleted": 1}`)),
+			}, nil
+		}
+		var lines []string
+		scanner := bufio.NewScanner(req.Body)
+		for scanner.Scan() {
+			sent++
+			lines = append(lines, `{"success": true}`)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(strings.Join(lines, "\n"))),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	indexer := client.NewBatchIndexer(collectionNameTest, BatchIndexerConfig{
+		ChangeDetector: NewChangeDetector(NewMemoryHashStore()),
+	})
+	document := map[string]interface{}{"id": "1", "name": "a"}
+	indexer.Add(document)
+	indexer.Delete("1")
+	indexer.Add(document)
+	if err := indexer.Close(context.Background()); err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if sent != 2 {
+		t.Errorf("Expected the document to be sent again after its delete, sent %d times", sent)
+	}
+}
+
+func TestClient_EnableChangeDetection(t *testing.T) {
+	var requests []string
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req.Method)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"id": "1", "name": "a"}`)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	client.EnableChangeDetection(NewChangeDetector(NewMemoryHashStore()))
+	document := map[string]interface{}{"id": "1", "name": "a"}
+	for _, response := range []*DocumentResponse{
+		client.UpsertDocument(collectionNameTest, document),
+		client.UpsertDocument(collectionNameTest, document),
+		client.DeleteDocument(collectionNameTest, "1"),
+		client.UpsertDocument(collectionNameTest, document),
+	} {
+		if response.Error != nil {
+			t.Fatalf("Expected to receive no errors, received %v", response.Error)
+		}
+	}
+	expected := []string{http.MethodPost, http.MethodDelete, http.MethodPost}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("Expected requests %v, received %v", expected, requests)
+	}
+	client.DisableChangeDetection()
+	client.UpsertDocument(collectionNameTest, document)
+	if len(requests) != 4 {
+		t.Errorf("Expected the document to be sent with change detection disabled")
+	}
+}
+
+func TestImportDocuments_changeDetectorSameID(t *testing.T) {
+	var sent []string
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		var lines []string
+		scanner := bufio.NewScanner(req.Body)
+		for scanner.Scan() {
+			sent = append(sent, scanner.Text())
+			lines = append(lines, `{"success": true}`)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(strings.Join(lines, "\n"))),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	detector := NewChangeDetector(NewMemoryHashStore())
+	b := map[string]interface{}{"id": "x", "v": "B"}
+	opts := ImportOptions{Action: ImportActionUpsert, ChangeDetector: detector}
+	if _, err := client.ImportDocuments(collectionNameTest, []interface{}{b}, &opts); err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	sent = nil
+	a := map[string]interface{}{"id": "x", "v": "A"}
+	report, err := client.ImportDocuments(collectionNameTest, []interface{}{a, b}, &opts)
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if len(sent) != 2 || report.NumImported != 2 || report.NumSkipped != 0 {
+		t.Errorf("Expected both versions of the document to be sent, sent %v", sent)
+	}
+}
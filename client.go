@@ -2,9 +2,11 @@ package typesense
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -19,6 +21,14 @@ type Client struct {
 	httpClient       httpClient
 	masterNode       *Node
 	readReplicaNodes []*Node
+	encoder          *DocumentEncoder
+
+	mu               sync.RWMutex
+	schemas          map[string]CollectionSchema
+	searchCache      *searchCache
+	validateSearches bool
+	searchSchemas    map[string]CollectionSchema
+	changeDetector   *ChangeDetector
 }
 
 // Node is a Typesense node, either the master or a read replica.
@@ -54,6 +64,13 @@ func NewClient(masterNode *Node, timeoutSeconds int, replicaNodes ...*Node) *Cli
 	return &client
 }
 
+// SetDocumentEncoder sets the encoder used to encode the documents sent
+// to Typesense and to decode retrieved documents. When no encoder is
+// set documents are encoded using encoding/json.
+func (c *Client) SetDocumentEncoder(encoder *DocumentEncoder) {
+	c.encoder = encoder
+}
+
 // Ping checks if the client has a connection with the Typesense API.
 func (c *Client) Ping() error {
 	if ok := c.Health(); !ok {
@@ -104,8 +121,27 @@ func (c *Client) Health() bool {
 }
 
 func (c *Client) apiCall(method, url string, body []byte) (*http.Response, error) {
+	return c.apiCallContext(context.Background(), method, url, body)
+}
+
+func (c *Client) apiCallContext(ctx context.Context, method, url string, body []byte) (*http.Response, error) {
 	req, _ := http.NewRequest(method, url, bytes.NewReader(body))
+	req = req.WithContext(ctx)
 	req.Header.Add(defaultHeaderKey, c.masterNode.APIKey)
 	req.Header.Add("Content-Type", "application/json")
 	return c.httpClient.Do(req)
 }
+
+func (c *Client) marshalDocument(document interface{}) ([]byte, error) {
+	if c.encoder != nil {
+		return c.encoder.Encode(document)
+	}
+	return json.Marshal(document)
+}
+
+func (c *Client) unmarshalDocument(data []byte, document interface{}) error {
+	if c.encoder != nil {
+		return c.encoder.Decode(data, document)
+	}
+	return json.Unmarshal(data, document)
+}
@@ -27,9 +27,15 @@ type Collection struct {
 
 // CollectionField is a Typesense collection field.
 type CollectionField struct {
-	Name  string `json:"name"`
-	Type  string `json:"type"`
-	Facet bool   `json:"facet"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Facet    bool   `json:"facet"`
+	Optional bool   `json:"optional,omitempty"`
+	Sort     bool   `json:"sort,omitempty"`
+
+	// NumDim is the number of dimensions of a float[] field used for
+	// vector search.
+	NumDim int `json:"num_dim,omitempty"`
 }
 
 // CreateCollection creates a new collection using the
@@ -128,6 +134,8 @@ func (c *Client) RetrieveCollection(collectionName string) (*Collection, error)
 
 // DeleteCollection deletes a collection by its name.
 func (c *Client) DeleteCollection(collectionName string) (*Collection, error) {
+	defer c.invalidateSearchCache(collectionName)
+	defer c.ForgetSearchSchema(collectionName)
 	method := http.MethodDelete
 	url := fmt.Sprintf(
 		"%s://%s:%s/%s/%s",
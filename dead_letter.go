@@ -0,0 +1,47 @@
+package typesense
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// DeadLetter is a document that could not be imported.
+type DeadLetter struct {
+	// Document is the JSON encoded document.
+	Document json.RawMessage `json:"document"`
+
+	// Error is the error message returned by Typesense.
+	Error string `json:"error"`
+
+	// Code is the status code returned by Typesense for the document.
+	Code int `json:"code,omitempty"`
+
+	// Attempts is the number of times the document was sent.
+	Attempts int `json:"attempts"`
+}
+
+// DeadLetterSink receives the documents that could not be imported.
+type DeadLetterSink interface {
+	WriteDeadLetter(letter DeadLetter) error
+}
+
+// JSONLDeadLetterSink is a DeadLetterSink that writes every dead letter
+// as a JSON line. It is safe for concurrent use.
+type JSONLDeadLetterSink struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewJSONLDeadLetterSink creates a JSONLDeadLetterSink that writes the
+// dead letters to w.
+func NewJSONLDeadLetterSink(w io.Writer) *JSONLDeadLetterSink {
+	return &JSONLDeadLetterSink{encoder: json.NewEncoder(w)}
+}
+
+// WriteDeadLetter writes the dead letter as a JSON line.
+func (s *JSONLDeadLetterSink) WriteDeadLetter(letter DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.encoder.Encode(letter)
+}
@@ -0,0 +1,204 @@
+package typesense
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestImportDocuments_deadLetter(t *testing.T) {
+	var attempts int
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		attempts++
+		var lines []string
+		scanner := bufio.NewScanner(req.Body)
+		for scanner.Scan() {
+			switch {
+			case strings.Contains(scanner.Text(), "lagging"):
+				lines = append(lines, `{"success": false, "error": "Not Ready or Lagging", "code": 503}`)
+			case strings.Contains(scanner.Text(), "invalid"):
+				lines = append(lines, `{"success": false, "error": "Bad document", "code": 400}`)
+			default:
+				lines = append(lines, `{"success": true}`)
+			}
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(strings.Join(lines, "\n"))),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	var deadLetters bytes.Buffer
+	documents := []interface{}{
+		testDocument,
+		testDocumentStruct{Field1: "lagging"},
+		testDocumentStruct{Field1: "invalid"},
+	}
+	report, err := client.ImportDocuments(collectionNameTest, documents, &ImportOptions{
+		MaxRetries:    2,
+		RetryInterval: time.Millisecond,
+		DeadLetter:    NewJSONLDeadLetterSink(&deadLetters),
+	})
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected %d import requests, received %d", 3, attempts)
+	}
+	if report.NumImported != 1 || report.NumFailed != 2 {
+		t.Errorf("Expected 1 imported and 2 failed documents, received %d and %d", report.NumImported, report.NumFailed)
+	}
+	var letters []DeadLetter
+	scanner := bufio.NewScanner(&deadLetters)
+	for scanner.Scan() {
+		var letter DeadLetter
+		if err := json.Unmarshal(scanner.Bytes(), &letter); err != nil {
+			t.Fatalf("Expected to receive no errors, received %v", err)
+		}
+		letters = append(letters, letter)
+	}
+	if len(letters) != 2 {
+		t.Fatalf("Expected %d dead letters, received %d", 2, len(letters))
+	}
+	if letters[0].Attempts != 3 || letters[0].Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected the retryable document to be sent 3 times, received %+v", letters[0])
+	}
+	if letters[1].Attempts != 1 || letters[1].Error != "Bad document" {
+		t.Errorf("Expected the invalid document to be sent once, received %+v", letters[1])
+	}
+	var document testDocumentStruct
+	json.Unmarshal(letters[1].Document, &document)
+	if document.Field1 != "invalid" {
+		t.Errorf("Expected the dead letter to contain the invalid document, received %s", letters[1].Document)
+	}
+}
+
+func TestImportResult_IsRetryable(t *testing.T) {
+	tests := []struct {
+		result    ImportResult
+		retryable bool
+	}{
+		{ImportResult{Success: true}, false},
+		{ImportResult{Code: http.StatusBadRequest}, false},
+		{ImportResult{Code: http.StatusConflict}, false},
+		{ImportResult{Code: http.StatusTooManyRequests}, true},
+		{ImportResult{Code: http.StatusServiceUnavailable}, true},
+	}
+	for _, test := range tests {
+		if retryable := test.result.IsRetryable(); retryable != test.retryable {
+			t.Errorf("Expected %+v retryable to be %v, received %v", test.result, test.retryable, retryable)
+		}
+	}
+}
+
+func TestImportDocuments_retryRequestError(t *testing.T) {
+	requestErr := errors.New("connection reset")
+	var attempts int
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts > 1 {
+			return nil, requestErr
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: ioutil.NopCloser(strings.NewReader(
+				`{"success": true}` + "\n" + `{"success": false, "error": "Not Ready or Lagging", "code": 503}`,
+			)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	var deadLetters bytes.Buffer
+	documents := []interface{}{testDocument, testDocumentStruct{Field1: "lagging"}}
+	report, err := client.ImportDocuments(collectionNameTest, documents, &ImportOptions{
+		MaxRetries:    2,
+		RetryInterval: time.Millisecond,
+		DeadLetter:    NewJSONLDeadLetterSink(&deadLetters),
+	})
+	if !errors.Is(err, requestErr) {
+		t.Errorf("Expected to receive error %v, received %v", requestErr, err)
+	}
+	if len(report.Results) != len(documents) || report.NumImported != 1 || report.NumFailed != 1 {
+		t.Fatalf("Expected 1 imported and 1 failed document, received %+v", report)
+	}
+	if report.Results[1].Error != err.Error() {
+		t.Errorf("Expected the pending document to fail with the request error, received %+v", report.Results[1])
+	}
+	var letter DeadLetter
+	if err := json.Unmarshal(deadLetters.Bytes(), &letter); err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if letter.Attempts != 2 || letter.Error != err.Error() {
+		t.Errorf("Expected a dead letter for the pending document, received %+v", letter)
+	}
+}
+
+func TestImportDocuments_retryRequest(t *testing.T) {
+	failures := []error{
+		nil,
+		&url.Error{Op: "Post", URL: "http://localhost:8108", Err: errors.New("connection reset")},
+	}
+	for _, failure := range failures {
+		var attempts int
+		mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+			attempts++
+			if attempts == 1 && failure != nil {
+				return nil, failure
+			}
+			if attempts == 1 {
+				return &http.Response{
+					StatusCode: http.StatusServiceUnavailable,
+					Body:       ioutil.NopCloser(strings.NewReader(`{"message": "Not Ready or Lagging"}`)),
+				}, nil
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(`{"success": true}` + "\n" + `{"success": true}`)),
+			}, nil
+		}
+		client := Client{
+			httpClient: mockClient,
+			masterNode: testMasterNode,
+		}
+		report, err := client.ImportDocuments(collectionNameTest, []interface{}{testDocument, testDocument}, &ImportOptions{
+			MaxRetries:    1,
+			RetryInterval: time.Millisecond,
+		})
+		if err != nil {
+			t.Fatalf("Expected to receive no errors, received %v", err)
+		}
+		if attempts != 2 || report.NumImported != 2 {
+			t.Errorf("Expected the failed request to be retried, received %d requests and %+v", attempts, report)
+		}
+	}
+}
+
+func TestImportRetryDelay(t *testing.T) {
+	tests := []struct {
+		interval time.Duration
+		attempt  int
+		expected time.Duration
+	}{
+		{100 * time.Millisecond, 1, 100 * time.Millisecond},
+		{100 * time.Millisecond, 3, 400 * time.Millisecond},
+		{100 * time.Millisecond, 1000, maxImportRetryInterval},
+		{time.Minute, 5, time.Minute},
+	}
+	for _, test := range tests {
+		if delay := importRetryDelay(test.interval, test.attempt); delay != test.expected {
+			t.Errorf("Expected delay %v for attempt %d, received %v", test.expected, test.attempt, delay)
+		}
+	}
+}
@@ -12,6 +12,8 @@ import (
 type DocumentResponse struct {
 	Data  []byte
 	Error error
+
+	encoder *DocumentEncoder
 }
 
 // UnmarshalDocument will unmarshal the document data into
@@ -20,6 +22,9 @@ func (ds *DocumentResponse) UnmarshalDocument(document interface{}) error {
 	if ds.Error != nil {
 		return ds.Error
 	}
+	if ds.encoder != nil {
+		return ds.encoder.Decode(ds.Data, document)
+	}
 	err := json.NewDecoder(bytes.NewReader(ds.Data)).Decode(&document)
 	return err
 }
@@ -1,6 +1,7 @@
 package typesense
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,6 +10,31 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
+)
+
+// retrieveDocumentsChunkSize is the maximum number of ids fetched by
+// each request of RetrieveDocuments.
+const retrieveDocumentsChunkSize = 250
+
+// Infix search modes of a field.
+const (
+	InfixOff      = "off"
+	InfixAlways   = "always"
+	InfixFallback = "fallback"
+)
+
+// Modes of splitting and joining the tokens of a query.
+const (
+	SplitJoinTokensOff      = "off"
+	SplitJoinTokensFallback = "fallback"
+	SplitJoinTokensAlways   = "always"
+)
+
+// Text match scores of documents that match multiple fields.
+const (
+	TextMatchTypeMaxScore  = "max_score"
+	TextMatchTypeMaxWeight = "max_weight"
 )
 
 // SearchResponse is the default Typesense response for a serch.
@@ -16,22 +42,130 @@ type SearchResponse struct {
 	FacetCounts []FacetCount      `json:"facet_counts"`
 	Found       int               `json:"found"`
 	Hits        []SearchResultHit `json:"hits"`
+
+	// FoundDocs is the number of documents found by a grouped search,
+	// Found is the number of groups found then.
+	FoundDocs int `json:"found_docs,omitempty"`
+
+	// GroupedHits are the hits of a search with GroupBy, grouped by
+	// the values of the group by fields.
+	GroupedHits []GroupedHit `json:"grouped_hits,omitempty"`
+
+	// OutOf is the number of documents in the collection.
+	OutOf int `json:"out_of"`
+
+	// Page is the page of the hits.
+	Page int `json:"page"`
+
+	// SearchTimeMs is the time the search took in the server, in
+	// milliseconds.
+	SearchTimeMs int `json:"search_time_ms"`
+
+	// RequestParams are the parameters of the search as understood
+	// by the server.
+	RequestParams *SearchRequestParams `json:"request_params,omitempty"`
+
+	// SearchCutoff is true when the search was cut off by the
+	// search_cutoff_ms parameter, so the results may be partial.
+	SearchCutoff bool `json:"search_cutoff,omitempty"`
+}
+
+// SearchRequestParams are the parameters of a search returned in its
+// response.
+type SearchRequestParams struct {
+	CollectionName string `json:"collection_name"`
+	PerPage        int    `json:"per_page"`
+	Q              string `json:"q"`
+}
+
+// GroupedHit is a group of hits of a grouped search.
+type GroupedHit struct {
+	// GroupKey are the values of the group by fields shared by the
+	// hits of the group, in the order of the group by fields.
+	GroupKey []interface{} `json:"group_key"`
+
+	// Found is the number of documents found in the group.
+	Found int `json:"found"`
+
+	// Hits are the hits of the group, at most GroupLimit.
+	Hits []SearchResultHit `json:"hits"`
 }
 
 // FacetCount is the representation of a Typesense facet count.
 type FacetCount struct {
-	FieldName string `json:"field_name"`
-	Counts    []struct {
-		Count int    `json:"count"`
-		Value string `json:"value"`
-	} `json:"counts"`
+	FieldName string            `json:"field_name"`
+	Counts    []FacetValueCount `json:"counts"`
+	Stats     *FacetStats       `json:"stats,omitempty"`
+}
+
+// FacetValueCount is the number of hits with a value of a facet field.
+type FacetValueCount struct {
+	Count int    `json:"count"`
+	Value string `json:"value"`
+
+	// Highlighted is the value with the matches of the facet query
+	// highlighted.
+	Highlighted string `json:"highlighted,omitempty"`
+}
+
+// FacetStats are the statistics of the values of a numeric facet
+// field. The statistics are nil for non numeric fields.
+type FacetStats struct {
+	Min         *float64 `json:"min,omitempty"`
+	Max         *float64 `json:"max,omitempty"`
+	Avg         *float64 `json:"avg,omitempty"`
+	Sum         *float64 `json:"sum,omitempty"`
+	TotalValues int      `json:"total_values,omitempty"`
 }
 
 // SearchResultHit represents a Typesense search result hit. Every
-// retrieved document from a search will have the type map[string]interface{}.
+// retrieved document from a search will have the type map[string]interface{},
+// use Decode to decode it into a struct.
 type SearchResultHit struct {
-	Highlights []SearchHighlight      `json:"highlights"`
-	Document   map[string]interface{} `json:"document"`
+	Highlights []SearchHighlight `json:"highlights"`
+
+	// Document is the document of the hit, it is decoded for every hit.
+	// Decode and DecodeHits decode RawDocument again into structs, the
+	// hits of TypedCollection.Search are decoded once, straight into
+	// their type.
+	Document map[string]interface{} `json:"document"`
+
+	// Highlight is the highlight of the matched fields, nested like
+	// the document. Each highlighted value is an object with its
+	// snippet and matched tokens.
+	Highlight map[string]interface{} `json:"highlight,omitempty"`
+
+	// TextMatch is the text match score of the hit.
+	TextMatch int64 `json:"text_match,omitempty"`
+
+	// TextMatchInfo details the text match score of the hit.
+	TextMatchInfo *TextMatchInfo `json:"text_match_info,omitempty"`
+
+	// GeoDistanceMeters are the distances in meters of the geopoint
+	// fields sorted by distance to the sorting point, by field name.
+	GeoDistanceMeters map[string]float64 `json:"geo_distance_meters,omitempty"`
+
+	// VectorDistance is the distance of the hit to the vector query.
+	VectorDistance *float64 `json:"vector_distance,omitempty"`
+
+	// HybridSearchInfo details the score of the hit in a hybrid search.
+	HybridSearchInfo *HybridSearchInfo `json:"hybrid_search_info,omitempty"`
+
+	// RawDocument is the JSON encoded document.
+	RawDocument json.RawMessage `json:"-"`
+
+	encoder *DocumentEncoder
+}
+
+// TextMatchInfo details the text match score of a hit.
+type TextMatchInfo struct {
+	BestFieldScore   string `json:"best_field_score"`
+	BestFieldWeight  int    `json:"best_field_weight"`
+	FieldsMatched    int    `json:"fields_matched"`
+	NumTokensDropped int    `json:"num_tokens_dropped"`
+	Score            string `json:"score"`
+	TokensMatched    int    `json:"tokens_matched"`
+	TypoPrefixScore  int    `json:"typo_prefix_score"`
 }
 
 // SearchHighlight represents the highlight of texts in the
@@ -64,10 +198,19 @@ type SearchOptions struct {
 	// results.
 	FilterBy []string
 
+	// Filter is a filter condition built with the filter constructors,
+	// it is combined with FilterBy conditions using &&.
+	Filter Filter
+
 	// SortBy list of numerical values and their corresponding sort order
 	// to sort results by.
 	SortBy []string
 
+	// Sort list of sort fields built with Asc, Desc, TextMatch, SeqID,
+	// Eval or GeoDistance, they are sorted after the SortBy values. At
+	// most three fields can be used between SortBy and Sort.
+	Sort []SortField
+
 	// FacetBy list of fields that will be used for faceting your results on.
 	FacetBy []string
 
@@ -106,6 +249,14 @@ type SearchOptions struct {
 	// Default is all fields will be snipped.
 	HighlightFullFields []string
 
+	// HighlightStartTag the start tag used for the highlighted snippets.
+	// Default value is <mark>.
+	HighlightStartTag *string
+
+	// HighlightEndTag the end tag used for the highlighted snippets.
+	// Default value is </mark>.
+	HighlightEndTag *string
+
 	// SnippetThreshold Field values under this length will be fully highlighted, instead
 	// of showing a snippet of relevant portion.
 	// Default value is 30.
@@ -128,36 +279,152 @@ type SearchOptions struct {
 
 	// HiddenHits list of records to unconditionally hide from search results.
 	Hiddenhits []string
+
+	// Preset is the name of a preset whose parameters are used for the
+	// search, the other options override them. QueryBy is not required
+	// when a preset is used, since the preset may define it.
+	Preset string
+
+	// QueryByWeights are the weights of the QueryBy fields in the text
+	// match score, in the same order.
+	QueryByWeights []int
+
+	// PrefixByField whether the query should be treated as a prefix or
+	// not for every QueryBy field, in the same order. It overrides
+	// Prefix.
+	PrefixByField []bool
+
+	// NumTyposByField number of typographical errors tolerated for every
+	// QueryBy field, in the same order. It overrides NumTypos.
+	NumTyposByField []int
+
+	// Infix infix search mode of every QueryBy field, in the same order,
+	// one of InfixOff, InfixAlways or InfixFallback. The fields must be
+	// indexed with infix enabled.
+	Infix []string
+
+	// PrioritizeExactMatch whether exact matches are ranked above other
+	// matches. Default value is true.
+	PrioritizeExactMatch *bool
+
+	// PrioritizeTokenPosition whether matches on the first tokens of a
+	// field are ranked above other matches. Default value is false.
+	PrioritizeTokenPosition *bool
+
+	// ExhaustiveSearch whether every token combination is considered
+	// instead of stopping once enough results are found. Default value
+	// is false.
+	ExhaustiveSearch *bool
+
+	// SearchCutoffMs maximum time in milliseconds the search may take,
+	// the results found until then are returned and the response is
+	// marked with SearchCutoff.
+	SearchCutoffMs *int
+
+	// EnableOverrides whether the curation overrides of the collection
+	// are applied. Default value is true.
+	EnableOverrides *bool
+
+	// UseCache whether the server caches the results of the search.
+	// Default value is false.
+	UseCache *bool
+
+	// CacheTTL number of seconds the server caches the results when
+	// UseCache is set. Default value is 60.
+	CacheTTL *int
+
+	// HighlightFields list of fields to highlight, default is all the
+	// QueryBy fields.
+	HighlightFields []string
+
+	// MinLen1Typo minimum length of a token for one typo to be tolerated.
+	// Default value is 4.
+	MinLen1Typo *int
+
+	// MinLen2Typo minimum length of a token for two typos to be
+	// tolerated. Default value is 7.
+	MinLen2Typo *int
+
+	// SplitJoinTokens whether tokens are split or joined to match, such
+	// as "basket ball" and "basketball", one of SplitJoinTokensOff,
+	// SplitJoinTokensFallback or SplitJoinTokensAlways.
+	SplitJoinTokens *string
+
+	// TextMatchType how the text match score of a document matching
+	// multiple fields is computed, one of TextMatchTypeMaxScore or
+	// TextMatchTypeMaxWeight.
+	TextMatchType *string
+
+	// FacetSamplePercent percentage of the hits sampled to compute the
+	// facet counts, between 1 and 100. Default value is 100.
+	FacetSamplePercent *int
+
+	// MaxCandidates maximum number of prefix or typo candidates of a
+	// token considered. Default value is 4.
+	MaxCandidates *int
+
+	// VectorQuery searches the nearest neighbours of a vector, combined
+	// with Query it makes a hybrid search. Searches with a vector query
+	// are sent in the body of a multi search request, since vectors are
+	// too long for the query string.
+	VectorQuery *VectorQuery
+
+	// SearchCacheTTL is the time the response of the search stays in
+	// the search cache of the client, see EnableSearchCache. It is not
+	// sent to Typesense. Default value is the TTL of the cache.
+	SearchCacheTTL time.Duration
 }
 
 func (opts *SearchOptions) encodeForm() (string, error) {
-	data := url.Values{}
 	if opts.Query == "" {
 		return "", ErrQueryRequired
 	}
-	data.Set("q", opts.Query)
-	if opts.QueryBy == nil || len(opts.QueryBy) == 0 {
+	if (opts.QueryBy == nil || len(opts.QueryBy) == 0) && opts.Preset == "" {
 		return "", ErrQueryByRequired
 	}
-	queryBy := strings.Join(opts.QueryBy, ",")
-	data.Set("query_by", queryBy)
-	opts.setOptionalFields(&data)
+	data, err := opts.encodeValues()
+	if err != nil {
+		return "", err
+	}
 	return data.Encode(), nil
 }
 
+// encodeValues encodes the options that are set, without requiring the
+// query and the query by fields, which may be given by the common
+// parameters of a multi search.
+func (opts *SearchOptions) encodeValues() (url.Values, error) {
+	data := url.Values{}
+	if opts.Query != "" {
+		data.Set("q", opts.Query)
+	}
+	if len(opts.QueryBy) > 0 {
+		queryBy := strings.Join(opts.QueryBy, ",")
+		data.Set("query_by", queryBy)
+	}
+	if len(opts.SortBy)+len(opts.Sort) > maxSortFields {
+		return nil, ErrTooManySortFields
+	}
+	opts.setOptionalFields(&data)
+	return data, nil
+}
+
 func (opts *SearchOptions) setOptionalFields(data *url.Values) {
 	if opts.MaxHits != nil {
 		data.Set("max_hits", strconv.Itoa(*opts.MaxHits))
 	}
-	if opts.Prefix != nil {
+	if len(opts.PrefixByField) > 0 {
+		prefixes := make([]string, len(opts.PrefixByField))
+		for i, prefix := range opts.PrefixByField {
+			prefixes[i] = strconv.FormatBool(prefix)
+		}
+		data.Set("prefix", strings.Join(prefixes, ","))
+	} else if opts.Prefix != nil {
 		data.Set("prefix", strconv.FormatBool(*opts.Prefix))
 	}
-	if opts.FilterBy != nil && len(opts.FilterBy) > 0 {
-		filterBy := strings.Join(opts.FilterBy, " && ")
+	if filterBy := opts.filterBy(); filterBy != "" {
 		data.Set("filter_by", filterBy)
 	}
-	if opts.SortBy != nil && len(opts.SortBy) > 0 {
-		sortBy := strings.Join(opts.SortBy, ",")
+	if sortBy := opts.sortBy(); sortBy != "" {
 		data.Set("sort_by", sortBy)
 	}
 	if opts.FacetBy != nil && len(opts.FacetBy) > 0 {
@@ -170,7 +437,9 @@ func (opts *SearchOptions) setOptionalFields(data *url.Values) {
 	if opts.FacetQuery != nil {
 		data.Set("facet_query", *opts.FacetQuery)
 	}
-	if opts.NumTypos != nil {
+	if len(opts.NumTyposByField) > 0 {
+		data.Set("num_typos", joinInts(opts.NumTyposByField))
+	} else if opts.NumTypos != nil {
 		data.Set("num_typos", strconv.Itoa(*opts.NumTypos))
 	}
 	if opts.Page != nil {
@@ -198,6 +467,12 @@ func (opts *SearchOptions) setOptionalFields(data *url.Values) {
 		highlightFullFields := strings.Join(opts.HighlightFullFields, ",")
 		data.Set("highlight_full_fields", highlightFullFields)
 	}
+	if opts.HighlightStartTag != nil {
+		data.Set("highlight_start_tag", *opts.HighlightStartTag)
+	}
+	if opts.HighlightEndTag != nil {
+		data.Set("highlight_end_tag", *opts.HighlightEndTag)
+	}
 	if opts.SnippetThreshold != nil {
 		data.Set("snippet_threshold", strconv.Itoa(*opts.SnippetThreshold))
 	}
@@ -215,11 +490,109 @@ func (opts *SearchOptions) setOptionalFields(data *url.Values) {
 		hiddenhits := strings.Join(opts.Hiddenhits, ",")
 		data.Set("hidden_hits", hiddenhits)
 	}
+	if len(opts.QueryByWeights) > 0 {
+		data.Set("query_by_weights", joinInts(opts.QueryByWeights))
+	}
+	if len(opts.Infix) > 0 {
+		data.Set("infix", strings.Join(opts.Infix, ","))
+	}
+	if opts.PrioritizeExactMatch != nil {
+		data.Set("prioritize_exact_match", strconv.FormatBool(*opts.PrioritizeExactMatch))
+	}
+	if opts.PrioritizeTokenPosition != nil {
+		data.Set("prioritize_token_position", strconv.FormatBool(*opts.PrioritizeTokenPosition))
+	}
+	if opts.ExhaustiveSearch != nil {
+		data.Set("exhaustive_search", strconv.FormatBool(*opts.ExhaustiveSearch))
+	}
+	if opts.SearchCutoffMs != nil {
+		data.Set("search_cutoff_ms", strconv.Itoa(*opts.SearchCutoffMs))
+	}
+	if opts.EnableOverrides != nil {
+		data.Set("enable_overrides", strconv.FormatBool(*opts.EnableOverrides))
+	}
+	if opts.UseCache != nil {
+		data.Set("use_cache", strconv.FormatBool(*opts.UseCache))
+	}
+	if opts.CacheTTL != nil {
+		data.Set("cache_ttl", strconv.Itoa(*opts.CacheTTL))
+	}
+	if len(opts.HighlightFields) > 0 {
+		data.Set("highlight_fields", strings.Join(opts.HighlightFields, ","))
+	}
+	if opts.MinLen1Typo != nil {
+		data.Set("min_len_1typo", strconv.Itoa(*opts.MinLen1Typo))
+	}
+	if opts.MinLen2Typo != nil {
+		data.Set("min_len_2typo", strconv.Itoa(*opts.MinLen2Typo))
+	}
+	if opts.SplitJoinTokens != nil {
+		data.Set("split_join_tokens", *opts.SplitJoinTokens)
+	}
+	if opts.TextMatchType != nil {
+		data.Set("text_match_type", *opts.TextMatchType)
+	}
+	if opts.FacetSamplePercent != nil {
+		data.Set("facet_sample_percent", strconv.Itoa(*opts.FacetSamplePercent))
+	}
+	if opts.MaxCandidates != nil {
+		data.Set("max_candidates", strconv.Itoa(*opts.MaxCandidates))
+	}
+	if opts.Preset != "" {
+		data.Set("preset", opts.Preset)
+	}
+	if opts.VectorQuery != nil {
+		data.Set("vector_query", opts.VectorQuery.String())
+	}
+}
+
+// joinInts joins the integers with commas.
+func joinInts(values []int) string {
+	strs := make([]string, len(values))
+	for i, value := range values {
+		strs[i] = strconv.Itoa(value)
+	}
+	return strings.Join(strs, ",")
+}
+
+// filterBy joins the FilterBy conditions and the Filter using &&.
+func (opts *SearchOptions) filterBy() string {
+	filters := opts.FilterBy
+	if opts.Filter != nil {
+		filter := opts.Filter.String()
+		if len(filters) > 0 && strings.Contains(filter, "||") {
+			filter = "(" + filter + ")"
+		}
+		if filter != "" {
+			filters = append(filters[:len(filters):len(filters)], filter)
+		}
+	}
+	return strings.Join(filters, " && ")
+}
+
+// sortBy joins the SortBy values and the Sort fields.
+func (opts *SearchOptions) sortBy() string {
+	sortBy := opts.SortBy
+	for _, field := range opts.Sort {
+		sortBy = append(sortBy[:len(sortBy):len(sortBy)], field.String())
+	}
+	return strings.Join(sortBy, ",")
 }
 
 // IndexDocument index a new document in the collection.
 func (c *Client) IndexDocument(collectionName string, document interface{}) *DocumentResponse {
-	documentResponse := DocumentResponse{}
+	return c.indexDocument(collectionName, document, "")
+}
+
+// UpsertDocument index a new document in the collection or replaces
+// the document with the same id if it already exists.
+func (c *Client) UpsertDocument(collectionName string, document interface{}) *DocumentResponse {
+	return c.indexDocument(collectionName, document, ImportActionUpsert)
+}
+
+func (c *Client) indexDocument(collectionName string, document interface{}, action string) *DocumentResponse {
+	defer c.invalidateSearchCache(collectionName)
+	documentResponse := DocumentResponse{encoder: c.encoder}
 	method := http.MethodPost
 	url := fmt.Sprintf(
 		"%s://%s:%s/%s/%s/documents",
@@ -229,7 +602,32 @@ func (c *Client) IndexDocument(collectionName string, document interface{}) *Doc
 		collectionsEndpoint,
 		collectionName,
 	)
-	body, _ := json.Marshal(document)
+	if action != "" {
+		url += "?action=" + action
+	}
+	body, err := c.marshalDocument(document)
+	if err != nil {
+		documentResponse.Error = err
+		return &documentResponse
+	}
+	if err := c.validateDocument(collectionName, action, body); err != nil {
+		documentResponse.Error = err
+		return &documentResponse
+	}
+	var id, hash string
+	detector := c.currentChangeDetector(nil)
+	if detector != nil {
+		var changed bool
+		id, hash, changed, err = detector.changed(collectionName, body)
+		if err != nil {
+			documentResponse.Error = err
+			return &documentResponse
+		}
+		if !changed {
+			documentResponse.Data = body
+			return &documentResponse
+		}
+	}
 	resp, err := c.apiCall(method, url, body)
 	if err != nil {
 		documentResponse.Error = err
@@ -254,12 +652,15 @@ func (c *Client) IndexDocument(collectionName string, document interface{}) *Doc
 		return &documentResponse
 	}
 	documentResponse.Data, documentResponse.Error = ioutil.ReadAll(resp.Body)
+	if documentResponse.Error == nil && detector != nil && resp.StatusCode < http.StatusMultipleChoices {
+		documentResponse.Error = detector.record(collectionName, id, hash)
+	}
 	return &documentResponse
 }
 
 // RetrieveDocument retrieves a document in the collection by its id.
 func (c *Client) RetrieveDocument(collectionName, documentID string) *DocumentResponse {
-	documentResponse := DocumentResponse{}
+	documentResponse := DocumentResponse{encoder: c.encoder}
 	method := http.MethodGet
 	url := fmt.Sprintf(
 		"%s://%s:%s/%s/%s/documents/%s",
@@ -289,7 +690,8 @@ func (c *Client) RetrieveDocument(collectionName, documentID string) *DocumentRe
 
 // DeleteDocument deletes a document in the collection by its id.
 func (c *Client) DeleteDocument(collectionName, documentID string) *DocumentResponse {
-	documentResponse := DocumentResponse{}
+	defer c.invalidateSearchCache(collectionName)
+	documentResponse := DocumentResponse{encoder: c.encoder}
 	method := http.MethodDelete
 	url := fmt.Sprintf(
 		"%s://%s:%s/%s/%s/documents/%s",
@@ -314,6 +716,9 @@ func (c *Client) DeleteDocument(collectionName, documentID string) *DocumentResp
 		return &documentResponse
 	}
 	documentResponse.Data, documentResponse.Error = ioutil.ReadAll(resp.Body)
+	if detector := c.currentChangeDetector(nil); documentResponse.Error == nil && detector != nil {
+		documentResponse.Error = detector.forget(collectionName, documentID)
+	}
 	return &documentResponse
 }
 
@@ -326,11 +731,51 @@ func (c *Client) Search(collectionName, query string, queryBy []string, searchOp
 			QueryBy: queryBy,
 		}
 	}
+	var searchResponse SearchResponse
+	if err := c.search(collectionName, searchOptions, &searchResponse); err != nil {
+		return nil, err
+	}
+	searchResponse.setEncoder(c.encoder)
+	return &searchResponse, nil
+}
+
+// search runs the search described by searchOptions and decodes the
+// response into v.
+func (c *Client) search(collectionName string, searchOptions *SearchOptions, v interface{}) error {
+	return c.searchContext(context.Background(), collectionName, searchOptions, v)
+}
+
+// searchContext searches the collection as search does, the request
+// is canceled when ctx is done. The response is served from the search
+// cache when it is enabled.
+func (c *Client) searchContext(ctx context.Context, collectionName string, searchOptions *SearchOptions, v interface{}) error {
 	urlEncodedForm, err := searchOptions.encodeForm()
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if err := c.validateSearch(collectionName, searchOptions); err != nil {
+		return err
+	}
+	fetch := func(ctx context.Context) ([]byte, error) {
+		if searchOptions.VectorQuery != nil {
+			return c.singleMultiSearch(ctx, collectionName, searchOptions)
+		}
+		return c.searchBody(ctx, collectionName, urlEncodedForm)
 	}
+	var body []byte
+	if cache := c.currentSearchCache(); cache != nil {
+		body, err = cache.get(ctx, collectionName, urlEncodedForm, searchOptions.SearchCacheTTL, fetch)
+	} else {
+		body, err = fetch(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
 
+// searchBody sends the search and returns the body of its response.
+func (c *Client) searchBody(ctx context.Context, collectionName, urlEncodedForm string) ([]byte, error) {
 	method := http.MethodGet
 	url := fmt.Sprintf(
 		"%s://%s:%s/%s/%s/documents/search?%s",
@@ -342,6 +787,7 @@ func (c *Client) Search(collectionName, query string, queryBy []string, searchOp
 		urlEncodedForm,
 	)
 	req, _ := http.NewRequest(method, url, nil)
+	req = req.WithContext(ctx)
 	req.Header.Add(defaultHeaderKey, c.masterNode.APIKey)
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -359,9 +805,94 @@ func (c *Client) Search(collectionName, query string, queryBy []string, searchOp
 		}
 		return nil, errors.New(apiResponse.Message)
 	}
-	var searchResponse SearchResponse
-	if err := json.NewDecoder(resp.Body).Decode(&searchResponse); err != nil {
-		return nil, err
+	return ioutil.ReadAll(resp.Body)
+}
+
+// DeleteDocuments deletes all documents in the collection that match
+// the filterBy condition, returning the number of deleted documents.
+// The hashes of the deleted documents are not removed from the change
+// detector of the client, their ids are unknown.
+func (c *Client) DeleteDocuments(collectionName, filterBy string) (int, error) {
+	defer c.invalidateSearchCache(collectionName)
+	query := url.Values{}
+	query.Set("filter_by", filterBy)
+	method := http.MethodDelete
+	url := fmt.Sprintf(
+		"%s://%s:%s/%s/%s/documents?%s",
+		c.masterNode.Protocol,
+		c.masterNode.Host,
+		c.masterNode.Port,
+		collectionsEndpoint,
+		collectionName,
+		query.Encode(),
+	)
+	resp, err := c.apiCall(method, url, nil)
+	if err != nil {
+		return 0, err
 	}
-	return &searchResponse, nil
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return 0, ErrCollectionNotFound
+	} else if resp.StatusCode == http.StatusUnauthorized {
+		return 0, ErrUnauthorized
+	} else if resp.StatusCode == http.StatusBadRequest {
+		var apiErr APIError
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil {
+			apiErr.Message = "status bad request"
+		}
+		return 0, apiErr
+	}
+	var deleteResponse struct {
+		NumDeleted int `json:"num_deleted"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&deleteResponse); err != nil {
+		return 0, err
+	}
+	return deleteResponse.NumDeleted, nil
+}
+
+// RetrievedDocuments are the documents retrieved by RetrieveDocuments.
+type RetrievedDocuments struct {
+	// IDs are the requested document ids in the order they were
+	// requested.
+	IDs []string
+
+	// Documents are the found documents keyed by their id.
+	Documents map[string]*DocumentResponse
+
+	// Missing are the requested ids that were not found, in the order
+	// they were requested.
+	Missing []string
+}
+
+// RetrieveDocuments retrieves the documents in the collection by their
+// ids. Documents are fetched in chunks by exporting the documents that
+// match an id filter, so ids may contain filter syntax characters.
+func (c *Client) RetrieveDocuments(collectionName string, ids []string) (*RetrievedDocuments, error) {
+	retrieved := RetrievedDocuments{
+		IDs:       ids,
+		Documents: make(map[string]*DocumentResponse, len(ids)),
+	}
+	for start := 0; start < len(ids); start += retrieveDocumentsChunkSize {
+		end := start + retrieveDocumentsChunkSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		opts := ExportOptions{FilterBy: idsFilter(ids[start:end])}
+		err := c.ExportDocuments(collectionName, &opts, func(document json.RawMessage) error {
+			if id, ok := lineDocumentID(document); ok {
+				retrieved.Documents[id] = &DocumentResponse{Data: document, encoder: c.encoder}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, id := range ids {
+		if _, ok := retrieved.Documents[id]; !ok {
+			retrieved.Missing = append(retrieved.Missing, id)
+		}
+	}
+	return &retrieved, nil
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strings"
 	"testing"
 )
@@ -81,6 +82,84 @@ func TestEncodeForm(t *testing.T) {
 	}
 }
 
+func TestEncodeForm_highlightTags(t *testing.T) {
+	startTag, endTag := "<em>", "</em>"
+	opts := SearchOptions{
+		Query:             "query",
+		QueryBy:           []string{"name"},
+		HighlightStartTag: &startTag,
+		HighlightEndTag:   &endTag,
+	}
+	form, err := opts.encodeForm()
+	if err != nil {
+		t.Fatalf("Expected no errors, received %v", err)
+	}
+	values, _ := url.ParseQuery(form)
+	if values.Get("highlight_start_tag") != startTag || values.Get("highlight_end_tag") != endTag {
+		t.Errorf("Expected the highlight tags to be encoded, received %q", form)
+	}
+}
+
+func TestEncodeForm_searchParameters(t *testing.T) {
+	numberValue := 2
+	prefix, enabled, disabled := true, true, false
+	splitJoinTokens, textMatchType := SplitJoinTokensAlways, TextMatchTypeMaxScore
+	opts := SearchOptions{
+		Query:                   "query",
+		QueryBy:                 []string{"name", "description"},
+		Prefix:                  &prefix,
+		PrefixByField:           []bool{true, false},
+		NumTypos:                &numberValue,
+		NumTyposByField:         []int{2, 1},
+		QueryByWeights:          []int{3, 1},
+		Infix:                   []string{InfixAlways, InfixOff},
+		PrioritizeExactMatch:    &disabled,
+		PrioritizeTokenPosition: &enabled,
+		ExhaustiveSearch:        &enabled,
+		SearchCutoffMs:          &numberValue,
+		EnableOverrides:         &disabled,
+		UseCache:                &enabled,
+		CacheTTL:                &numberValue,
+		HighlightFields:         []string{"name"},
+		MinLen1Typo:             &numberValue,
+		MinLen2Typo:             &numberValue,
+		SplitJoinTokens:         &splitJoinTokens,
+		TextMatchType:           &textMatchType,
+		FacetSamplePercent:      &numberValue,
+		MaxCandidates:           &numberValue,
+	}
+	form, err := opts.encodeForm()
+	if err != nil {
+		t.Fatalf("Expected no errors, received %v", err)
+	}
+	values, _ := url.ParseQuery(form)
+	expected := map[string]string{
+		"prefix":                    "true,false",
+		"num_typos":                 "2,1",
+		"query_by_weights":          "3,1",
+		"infix":                     "always,off",
+		"prioritize_exact_match":    "false",
+		"prioritize_token_position": "true",
+		"exhaustive_search":         "true",
+		"search_cutoff_ms":          "2",
+		"enable_overrides":          "false",
+		"use_cache":                 "true",
+		"cache_ttl":                 "2",
+		"highlight_fields":          "name",
+		"min_len_1typo":             "2",
+		"min_len_2typo":             "2",
+		"split_join_tokens":         "always",
+		"text_match_type":           "max_score",
+		"facet_sample_percent":      "2",
+		"max_candidates":            "2",
+	}
+	for key, value := range expected {
+		if values.Get(key) != value {
+			t.Errorf("Expected %s to be %q, received %q", key, value, values.Get(key))
+		}
+	}
+}
+
 func TestIndexDocument(t *testing.T) {
 	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
 		documentJSON, _ := json.Marshal(testDocument)
@@ -265,3 +344,59 @@ func TestSearch_badRequest(t *testing.T) {
 		t.Errorf("Expected to receive error %q, received %q", errorMessage, err.Error())
 	}
 }
+
+func TestDeleteDocuments(t *testing.T) {
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		if filterBy := req.URL.Query().Get("filter_by"); filterBy != "id:[a,`b c`]" {
+			t.Errorf("Expected filter_by %q, received %q", "id:[a,`b c`]", filterBy)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"num_deleted": 2}`)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	numDeleted, err := client.DeleteDocuments(collectionNameTest, idsFilter([]string{"a", "b c"}))
+	if err != nil {
+		t.Errorf("Expected to receive no errors, received %v", err)
+	}
+	if numDeleted != 2 {
+		t.Errorf("Expected to delete %d documents, deleted %d", 2, numDeleted)
+	}
+}
+
+func TestRetrieveDocuments(t *testing.T) {
+	var filters []string
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		filters = append(filters, req.URL.Query().Get("filter_by"))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: ioutil.NopCloser(strings.NewReader(
+				`{"id": "b,1", "field1": "b"}` + "\n" + `{"id": "a", "field1": "a"}`,
+			)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	ids := []string{"a", "missing", "b,1"}
+	retrieved, err := client.RetrieveDocuments(collectionNameTest, ids)
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	expectedFilter := "id:[a,missing,`b,1`]"
+	if len(filters) != 1 || filters[0] != expectedFilter {
+		t.Errorf("Expected to filter by %q, received %v", expectedFilter, filters)
+	}
+	if len(retrieved.Missing) != 1 || retrieved.Missing[0] != "missing" {
+		t.Errorf("Expected id %q to be missing, received %v", "missing", retrieved.Missing)
+	}
+	var document testDocumentStruct
+	if err := retrieved.Documents["b,1"].UnmarshalDocument(&document); err != nil || document.Field1 != "b" {
+		t.Errorf("Expected to retrieve document %q, received %v", "b,1", document)
+	}
+}
@@ -0,0 +1,533 @@
+package typesense
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TimeFormat is the representation of time.Time values in the
+// documents encoded by a DocumentEncoder.
+type TimeFormat int
+
+const (
+	// TimeUnixSeconds encodes time.Time values as the number of seconds
+	// elapsed since January 1, 1970 UTC.
+	TimeUnixSeconds TimeFormat = iota
+
+	// TimeUnixMilliseconds encodes time.Time values as the number of
+	// milliseconds elapsed since January 1, 1970 UTC.
+	TimeUnixMilliseconds
+)
+
+const (
+	typesenseTagKey = "typesense"
+	typesenseTagID  = "id"
+)
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	jsonMarshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	interfaceType       = reflect.TypeOf((*interface{})(nil)).Elem()
+
+	structFieldsCache sync.Map
+)
+
+// DocumentEncoder encodes Go structs into Typesense documents and
+// decodes them back. It follows the encoding/json conventions with the
+// following differences:
+//
+// The field tagged with `typesense:"id"` is encoded as the document id,
+// it can be of any scalar type and is always sent as a string.
+//
+// time.Time values are encoded as unix timestamps using TimeFormat, so
+// they can be sorted and filtered numerically.
+//
+// Nil pointers, interfaces, maps and slices are omitted from the
+// document instead of being encoded as null, the values of maps such as
+// map[string]interface{} documents included.
+type DocumentEncoder struct {
+	// TimeFormat is the format of encoded time.Time values. Default
+	// value is TimeUnixSeconds.
+	TimeFormat TimeFormat
+}
+
+// Encode returns the JSON encoding of the document.
+func (e *DocumentEncoder) Encode(document interface{}) ([]byte, error) {
+	value, _, err := e.encodeValue(reflect.ValueOf(document))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+// Decode parses the JSON-encoded document into the value pointed to
+// by document, reversing the conversions made by Encode.
+func (e *DocumentEncoder) Decode(data []byte, document interface{}) error {
+	v := reflect.ValueOf(document)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("typesense: decode of document into non-pointer %T", document)
+	}
+	return e.decodeValue(data, v.Elem())
+}
+
+// encodeValue converts v into a value that encoding/json marshals as
+// the Typesense document representation. The returned boolean is false
+// when the value must be omitted.
+func (e *DocumentEncoder) encodeValue(v reflect.Value) (interface{}, bool, error) {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil, false, nil
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil, false, nil
+		}
+		return e.encodeValue(v.Elem())
+	case reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return nil, false, nil
+		}
+	}
+	if v.Type() == timeType {
+		return e.encodeTime(v.Interface().(time.Time)), true, nil
+	}
+	if v.Type().Implements(jsonMarshalerType) {
+		return v.Interface(), true, nil
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		document, err := e.encodeStruct(v)
+		if err != nil {
+			return nil, false, err
+		}
+		return document, true, nil
+	case reflect.Map:
+		values := reflect.MakeMapWithSize(reflect.MapOf(v.Type().Key(), interfaceType), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			value, ok, err := e.encodeValue(iter.Value())
+			if err != nil {
+				return nil, false, err
+			}
+			if ok {
+				values.SetMapIndex(iter.Key(), reflect.ValueOf(&value).Elem())
+			}
+		}
+		return values.Interface(), true, nil
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Interface(), true, nil
+		}
+		values := make([]interface{}, v.Len())
+		for i := range values {
+			value, _, err := e.encodeValue(v.Index(i))
+			if err != nil {
+				return nil, false, err
+			}
+			values[i] = value
+		}
+		return values, true, nil
+	}
+	return v.Interface(), true, nil
+}
+
+func (e *DocumentEncoder) encodeStruct(v reflect.Value) (map[string]interface{}, error) {
+	document := make(map[string]interface{})
+	for _, field := range structFields(v.Type()) {
+		fieldValue, ok := structFieldValue(v, field.index, false)
+		if !ok {
+			continue
+		}
+		if field.id {
+			id, err := encodeDocumentID(fieldValue)
+			if err != nil {
+				return nil, fmt.Errorf("typesense: field %s: %v", field.fieldName, err)
+			}
+			document[typesenseTagID] = id
+			continue
+		}
+		if field.omitEmpty && isEmptyValue(fieldValue) {
+			continue
+		}
+		value, ok, err := e.encodeValue(fieldValue)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			document[field.name] = value
+		}
+	}
+	return document, nil
+}
+
+func (e *DocumentEncoder) encodeTime(t time.Time) int64 {
+	if e.TimeFormat == TimeUnixMilliseconds {
+		return t.UnixNano() / int64(time.Millisecond)
+	}
+	return t.Unix()
+}
+
+func (e *DocumentEncoder) decodeValue(data []byte, v reflect.Value) error {
+	if v.Kind() == reflect.Ptr {
+		if string(data) == "null" {
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return e.decodeValue(data, v.Elem())
+	}
+	if v.Type() == timeType {
+		return e.decodeTime(data, v)
+	}
+	if reflect.PtrTo(v.Type()).Implements(jsonUnmarshalerType) {
+		return json.Unmarshal(data, v.Addr().Interface())
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return err
+		}
+		return e.decodeStruct(fields, v)
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			break
+		}
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(data, &values); err != nil {
+			return err
+		}
+		if values == nil {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		m := reflect.MakeMapWithSize(v.Type(), len(values))
+		for key, value := range values {
+			element := reflect.New(v.Type().Elem()).Elem()
+			if err := e.decodeValue(value, element); err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(key).Convert(v.Type().Key()), element)
+		}
+		v.Set(m)
+		return nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			break
+		}
+		var values []json.RawMessage
+		if err := json.Unmarshal(data, &values); err != nil {
+			return err
+		}
+		if values == nil {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		slice := reflect.MakeSlice(v.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := e.decodeValue(value, slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	}
+	return json.Unmarshal(data, v.Addr().Interface())
+}
+
+func (e *DocumentEncoder) decodeStruct(fields map[string]json.RawMessage, v reflect.Value) error {
+	for _, field := range structFields(v.Type()) {
+		data, ok := fields[field.name]
+		if !ok {
+			continue
+		}
+		fieldValue, ok := structFieldValue(v, field.index, true)
+		if !ok {
+			continue
+		}
+		var err error
+		if field.id {
+			err = decodeDocumentID(data, fieldValue)
+		} else {
+			err = e.decodeValue(data, fieldValue)
+		}
+		if err != nil {
+			return fmt.Errorf("typesense: field %s: %v", field.fieldName, err)
+		}
+	}
+	return nil
+}
+
+// decodeTime parses a unix timestamp in TimeFormat into v, the time is
+// set in UTC.
+func (e *DocumentEncoder) decodeTime(data []byte, v reflect.Value) error {
+	var timestamp int64
+	if err := json.Unmarshal(data, &timestamp); err != nil {
+		return err
+	}
+	var t time.Time
+	if e.TimeFormat == TimeUnixMilliseconds {
+		t = time.Unix(0, timestamp*int64(time.Millisecond))
+	} else {
+		t = time.Unix(timestamp, 0)
+	}
+	v.Set(reflect.ValueOf(t.UTC()))
+	return nil
+}
+
+// documentField is the encoding information of a struct field.
+type documentField struct {
+	name      string
+	tagged    bool
+	omitEmpty bool
+	id        bool
+	embedded  bool
+}
+
+// structField is a field of a struct document, reached through the
+// fields of the embedded structs in index.
+type structField struct {
+	documentField
+	fieldName string
+	index     []int
+}
+
+// structFields returns the fields of the struct type in its documents,
+// named id for the id field. As in encoding/json the fields of embedded
+// structs are promoted and, among the fields with the same name, the
+// shallowest one is kept. At the same depth the id field is preferred
+// to a tagged field, which is preferred to an untagged one, the fields
+// are dropped when it is still ambiguous.
+func structFields(t reflect.Type) []structField {
+	if fields, ok := structFieldsCache.Load(t); ok {
+		return fields.([]structField)
+	}
+	type candidate struct {
+		field structField
+		depth int
+		rank  int
+	}
+	var candidates []candidate
+	visited := make(map[reflect.Type]bool)
+	var walk func(t reflect.Type, index []int, depth int)
+	walk = func(t reflect.Type, index []int, depth int) {
+		if visited[t] {
+			return
+		}
+		visited[t] = true
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			info, ok := parseDocumentField(field)
+			if !ok {
+				continue
+			}
+			fieldIndex := append(append([]int{}, index...), i)
+			if info.embedded {
+				embedded := field.Type
+				if embedded.Kind() == reflect.Ptr {
+					embedded = embedded.Elem()
+				}
+				walk(embedded, fieldIndex, depth+1)
+				continue
+			}
+			rank := 0
+			if info.id {
+				info.name, rank = typesenseTagID, 2
+			} else if info.tagged {
+				rank = 1
+			}
+			candidates = append(candidates, candidate{
+				field: structField{documentField: info, fieldName: field.Name, index: fieldIndex},
+				depth: depth,
+				rank:  rank,
+			})
+		}
+		visited[t] = false
+	}
+	walk(t, nil, 0)
+
+	dominant := make(map[string]int)
+	ambiguous := make(map[string]bool)
+	for i, c := range candidates {
+		j, ok := dominant[c.field.name]
+		if !ok {
+			dominant[c.field.name] = i
+			continue
+		}
+		other := candidates[j]
+		switch {
+		case c.depth < other.depth || (c.depth == other.depth && c.rank > other.rank):
+			dominant[c.field.name] = i
+			ambiguous[c.field.name] = false
+		case c.depth == other.depth && c.rank == other.rank:
+			ambiguous[c.field.name] = true
+		}
+	}
+	fields := make([]structField, 0, len(dominant))
+	for i, c := range candidates {
+		if dominant[c.field.name] == i && !ambiguous[c.field.name] {
+			fields = append(fields, c.field)
+		}
+	}
+	structFieldsCache.Store(t, fields)
+	return fields
+}
+
+// structFieldValue returns the field of v at index, following the
+// pointers to embedded structs. Nil pointers are allocated when alloc
+// is true, otherwise the field is not found.
+func structFieldValue(v reflect.Value, index []int, alloc bool) (reflect.Value, bool) {
+	for i, fieldIndex := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !alloc || !v.CanSet() {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(fieldIndex)
+	}
+	return v, true
+}
+
+// parseDocumentField parses the json and typesense tags of the field,
+// returning false when the field must be ignored.
+func parseDocumentField(field reflect.StructField) (documentField, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return documentField{}, false
+	}
+	parts := strings.Split(tag, ",")
+	info := documentField{
+		name:   parts[0],
+		tagged: parts[0] != "",
+		id:     field.Tag.Get(typesenseTagKey) == typesenseTagID,
+	}
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			info.omitEmpty = true
+		}
+	}
+	if field.Anonymous && info.name == "" && !info.id {
+		t := field.Type
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() == reflect.Struct {
+			info.embedded = true
+			return info, true
+		}
+	}
+	if field.PkgPath != "" {
+		return documentField{}, false
+	}
+	if info.name == "" {
+		info.name = field.Name
+	}
+	return info, true
+}
+
+// encodeDocumentID formats a scalar id field as a string.
+func encodeDocumentID(v reflect.Value) (string, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", fmt.Errorf("document id is nil")
+		}
+		v = v.Elem()
+	}
+	if marshaler, ok := v.Interface().(encoding.TextMarshaler); ok {
+		id, err := marshaler.MarshalText()
+		return string(id), err
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	}
+	return "", fmt.Errorf("document id of type %s is not a scalar", v.Type())
+}
+
+// decodeDocumentID parses the string document id into a scalar id field.
+func decodeDocumentID(data []byte, v reflect.Value) error {
+	var id string
+	if err := json.Unmarshal(data, &id); err != nil {
+		return err
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	if reflect.PtrTo(v.Type()).Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(id))
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(id)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(id, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(id, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(id, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(id)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	default:
+		return fmt.Errorf("document id of type %s is not a scalar", v.Type())
+	}
+	return nil
+}
+
+// isEmptyValue reports whether v is empty following the encoding/json
+// omitempty rules.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
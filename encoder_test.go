@@ -0,0 +1,178 @@
+package typesense
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testEncodedBase struct {
+	Title string `json:"title"`
+}
+
+type testEncodedDocument struct {
+	testEncodedBase
+	ProductID   int64       `json:"product_id" typesense:"id"`
+	CreatedAt   time.Time   `json:"created_at"`
+	Dates       []time.Time `json:"dates"`
+	Description *string     `json:"description"`
+	Rating      *float64    `json:"rating"`
+	Tags        []string    `json:"tags"`
+	Hidden      string      `json:"-"`
+	Count       int         `json:"count,omitempty"`
+}
+
+func TestDocumentEncoder_Encode(t *testing.T) {
+	rating := 4.5
+	createdAt := time.Date(2020, 9, 10, 12, 0, 0, int(500*time.Millisecond), time.UTC)
+	document := testEncodedDocument{
+		testEncodedBase: testEncodedBase{Title: "title"},
+		ProductID:       42,
+		CreatedAt:       createdAt,
+		Dates:           []time.Time{createdAt},
+		Rating:          &rating,
+		Hidden:          "hidden",
+	}
+	tests := []struct {
+		timeFormat TimeFormat
+		timestamp  float64
+	}{
+		{TimeUnixSeconds, float64(createdAt.Unix())},
+		{TimeUnixMilliseconds, float64(createdAt.Unix()*1000 + 500)},
+	}
+	for _, test := range tests {
+		encoder := DocumentEncoder{TimeFormat: test.timeFormat}
+		data, err := encoder.Encode(document)
+		if err != nil {
+			t.Fatalf("Expected to receive no errors, received %v", err)
+		}
+		var encoded map[string]interface{}
+		json.Unmarshal(data, &encoded)
+		expected := map[string]interface{}{
+			"id":         "42",
+			"title":      "title",
+			"created_at": test.timestamp,
+			"dates":      []interface{}{test.timestamp},
+			"rating":     rating,
+		}
+		if !reflect.DeepEqual(encoded, expected) {
+			t.Errorf("Expected to receive %v, received %v", expected, encoded)
+		}
+	}
+}
+
+func TestDocumentEncoder_Encode_invalidID(t *testing.T) {
+	document := struct {
+		ID []int `typesense:"id"`
+	}{ID: []int{1}}
+	encoder := DocumentEncoder{}
+	if _, err := encoder.Encode(document); err == nil {
+		t.Errorf("Expected to receive an error for a non scalar id")
+	}
+}
+
+func TestDocumentEncoder_Decode(t *testing.T) {
+	description := "description"
+	rating := 4.5
+	createdAt := time.Date(2020, 9, 10, 12, 0, 0, int(500*time.Millisecond), time.UTC)
+	document := testEncodedDocument{
+		testEncodedBase: testEncodedBase{Title: "title"},
+		ProductID:       42,
+		CreatedAt:       createdAt,
+		Dates:           []time.Time{createdAt},
+		Description:     &description,
+		Rating:          &rating,
+		Tags:            []string{"tag"},
+		Count:           3,
+	}
+	encoder := DocumentEncoder{TimeFormat: TimeUnixMilliseconds}
+	data, err := encoder.Encode(document)
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	var decoded testEncodedDocument
+	if err := encoder.Decode(data, &decoded); err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if !reflect.DeepEqual(decoded, document) {
+		t.Errorf("Expected to receive %+v, received %+v", document, decoded)
+	}
+}
+
+func TestClient_SetDocumentEncoder(t *testing.T) {
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		body, _ := ioutil.ReadAll(req.Body)
+		var document map[string]interface{}
+		json.Unmarshal(body, &document)
+		if document["id"] != "7" {
+			t.Errorf("Expected to send document id %q, sent %v", "7", document["id"])
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(body)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	client.SetDocumentEncoder(&DocumentEncoder{})
+	document := testEncodedDocument{ProductID: 7, CreatedAt: time.Unix(1600000000, 0).UTC()}
+	var indexed testEncodedDocument
+	if err := client.IndexDocument(collectionNameTest, document).UnmarshalDocument(&indexed); err != nil {
+		t.Errorf("Expected to receive no errors, received %v", err)
+	}
+	if !reflect.DeepEqual(indexed, document) {
+		t.Errorf("Expected to receive %+v, received %+v", document, indexed)
+	}
+}
+
+func TestDocumentEncoder_Encode_map(t *testing.T) {
+	var rating *float64
+	document := map[string]interface{}{
+		"at":     time.Unix(10, 0).UTC(),
+		"p":      rating,
+		"nested": map[string]interface{}{"at": time.Unix(20, 0)},
+		"title":  "title",
+	}
+	encoder := DocumentEncoder{}
+	data, err := encoder.Encode(document)
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if expected := `{"at":10,"nested":{"at":20},"title":"title"}`; string(data) != expected {
+		t.Errorf("Expected to receive %s, received %s", expected, data)
+	}
+}
+
+func TestDocumentEncoder_embeddedFieldDepth(t *testing.T) {
+	type inner struct {
+		Name  string `json:"name"`
+		Inner string `json:"inner"`
+	}
+	type outer struct {
+		inner
+		Name string `json:"name"`
+	}
+	document := outer{inner: inner{Name: "inner", Inner: "inner"}, Name: "outer"}
+	expected, _ := json.Marshal(document)
+	encoder := DocumentEncoder{}
+	data, err := encoder.Encode(document)
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if string(data) != string(expected) {
+		t.Errorf("Expected to receive %s, received %s", expected, data)
+	}
+	var decoded outer
+	if err := encoder.Decode([]byte(`{"name": "outer", "inner": "inner"}`), &decoded); err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if decoded.Name != "outer" || decoded.inner.Name != "" || decoded.Inner != "inner" {
+		t.Errorf("Expected the outer field to be decoded, received %+v", decoded)
+	}
+}
@@ -34,6 +34,10 @@ var ErrQueryRequired = errors.New("query field is required")
 // `query_by` is a required field.
 var ErrQueryByRequired = errors.New("query by field is required")
 
+// ErrTooManySortFields returned when the user tries to sort by more fields
+// than Typesense allows.
+var ErrTooManySortFields = errors.New("at most three sort fields are allowed")
+
 // ErrUnauthorized returned when the API key does not match the Typesense API key.
 var ErrUnauthorized = errors.New("the api key does not match the Typesense api key")
 
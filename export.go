@@ -0,0 +1,115 @@
+package typesense
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const exportProgressInterval = 100
+
+// ExportOptions are the options used to export the documents of a
+// collection.
+type ExportOptions struct {
+	// FilterBy is a filter condition to export only the matching
+	// documents.
+	FilterBy string
+
+	// IncludeFields list of fields from the documents to export.
+	IncludeFields []string
+
+	// ExcludeFields list of fields from the documents to not export.
+	ExcludeFields []string
+
+	// Progress receives the progress of the export.
+	Progress ProgressObserver
+}
+
+// ExportDocuments exports the documents of the collection, calling fn
+// with every JSON encoded document as it is received. The export stops
+// with the error returned by fn, if any.
+func (c *Client) ExportDocuments(collectionName string, opts *ExportOptions, fn func(document json.RawMessage) error) error {
+	if opts == nil {
+		opts = &ExportOptions{}
+	}
+	query := url.Values{}
+	if opts.FilterBy != "" {
+		query.Set("filter_by", opts.FilterBy)
+	}
+	if len(opts.IncludeFields) > 0 {
+		query.Set("include_fields", strings.Join(opts.IncludeFields, ","))
+	}
+	if len(opts.ExcludeFields) > 0 {
+		query.Set("exclude_fields", strings.Join(opts.ExcludeFields, ","))
+	}
+	method := http.MethodGet
+	url := fmt.Sprintf(
+		"%s://%s:%s/%s/%s/documents/export",
+		c.masterNode.Protocol,
+		c.masterNode.Host,
+		c.masterNode.Port,
+		collectionsEndpoint,
+		collectionName,
+	)
+	if len(query) > 0 {
+		url += "?" + query.Encode()
+	}
+	resp, err := c.apiCall(method, url, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrCollectionNotFound
+	} else if resp.StatusCode == http.StatusUnauthorized {
+		return ErrUnauthorized
+	} else if resp.StatusCode == http.StatusBadRequest {
+		var apiErr APIError
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil {
+			apiErr.Message = "status bad request"
+		}
+		return apiErr
+	} else if resp.StatusCode != http.StatusOK {
+		responseBody, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		return HTTPError{
+			Status:       resp.StatusCode,
+			ResponseBody: responseBody,
+		}
+	}
+	tracker := newProgressTracker(opts.Progress, 0)
+	processed := 0
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		tracker.addBytes(int64(len(scanner.Bytes()) + 1))
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		document := make(json.RawMessage, len(line))
+		copy(document, line)
+		if err := fn(document); err != nil {
+			return err
+		}
+		processed++
+		if processed == exportProgressInterval {
+			tracker.addDocuments(processed, 0)
+			processed = 0
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if processed > 0 {
+		tracker.addDocuments(processed, 0)
+	}
+	return nil
+}
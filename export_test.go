@@ -0,0 +1,69 @@
+package typesense
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestExportDocuments(t *testing.T) {
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		if filterBy := req.URL.Query().Get("filter_by"); filterBy != "field2:>5" {
+			t.Errorf("Expected filter_by %q, received %q", "field2:>5", filterBy)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: ioutil.NopCloser(strings.NewReader(
+				`{"field1": "a", "field2": 10}` + "\n" + `{"field1": "b", "field2": 20}`,
+			)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	var documents []testDocumentStruct
+	var progress Progress
+	opts := ExportOptions{
+		FilterBy: "field2:>5",
+		Progress: ProgressObserverFunc(func(p Progress) { progress = p }),
+	}
+	err := client.ExportDocuments(collectionNameTest, &opts, func(document json.RawMessage) error {
+		var d testDocumentStruct
+		if err := json.Unmarshal(document, &d); err != nil {
+			return err
+		}
+		documents = append(documents, d)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if len(documents) != 2 || documents[1].Field1 != "b" {
+		t.Errorf("Expected to export 2 documents, received %v", documents)
+	}
+	if progress.Processed != 2 || progress.Bytes == 0 {
+		t.Errorf("Expected progress of 2 documents, received %+v", progress)
+	}
+}
+
+func TestExportDocuments_collectionNotFound(t *testing.T) {
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"message": "collection not found"}`)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	err := client.ExportDocuments(collectionNameTest, nil, func(document json.RawMessage) error {
+		return nil
+	})
+	if err != ErrCollectionNotFound {
+		t.Errorf("Expected to receive error %v, received %v", ErrCollectionNotFound, err)
+	}
+}
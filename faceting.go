@@ -0,0 +1,169 @@
+package typesense
+
+import "sort"
+
+// FacetRefinements are the values selected by the user for every facet
+// field. Hits must have one of the selected values of every refined
+// field.
+type FacetRefinements map[string][]string
+
+// FacetState is the result of a disjunctive facet search, ready to be
+// rendered as a faceted navigation.
+type FacetState struct {
+	// Response is the response of the search with every refinement
+	// applied, its hits are the hits to show.
+	Response *SearchResponse
+
+	// Facets are the facets of the FacetBy fields, in the same order,
+	// followed by the facets of refined fields not in FacetBy.
+	Facets []FacetFieldState
+
+	// FilterBy is the filter_by of the search with every refinement
+	// applied, combined with the filters of the search options.
+	FilterBy string
+}
+
+// FacetFieldState is the state of a facet field in a FacetState.
+type FacetFieldState struct {
+	FieldName string
+	Values    []FacetValueState
+	Stats     *FacetStats
+}
+
+// FacetValueState is the state of a facet value in a FacetFieldState.
+type FacetValueState struct {
+	FacetValueCount
+
+	// Selected is true when the value is one of the refinements of
+	// the field.
+	Selected bool
+}
+
+// DisjunctiveFacetSearch runs the search with the refinements applied
+// and computes the facets disjunctively: the counts of a refined field
+// are computed without the refinements of the field itself, so the
+// other values of the field can still be selected. The search and one
+// facet search per refined field are sent in a single multi search.
+// Selected values that have no hits are included with a count of 0.
+// searchOptions must have the Query and QueryBy fields set.
+func (c *Client) DisjunctiveFacetSearch(collectionName string, searchOptions *SearchOptions, refinements FacetRefinements) (*FacetState, error) {
+	if searchOptions == nil {
+		return nil, ErrQueryRequired
+	}
+	refinedFields := make([]string, 0, len(refinements))
+	for field, values := range refinements {
+		if len(values) > 0 {
+			refinedFields = append(refinedFields, field)
+		}
+	}
+	sort.Strings(refinedFields)
+
+	mainOptions := refinedSearchOptions(searchOptions, refinements, "")
+	searches := []MultiSearchRequest{{Collection: collectionName, Options: mainOptions}}
+	for _, field := range refinedFields {
+		facetOptions := refinedSearchOptions(searchOptions, refinements, field)
+		perPage := 0
+		facetOptions.FacetBy = []string{field}
+		facetOptions.Page = nil
+		facetOptions.PerPage = &perPage
+		searches = append(searches, MultiSearchRequest{Collection: collectionName, Options: facetOptions})
+	}
+	results, err := c.MultiSearch(nil, searches)
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		if result.Err != nil {
+			return nil, result.Err
+		}
+	}
+
+	facetCounts := make(map[string]FacetCount)
+	for _, facetCount := range results[0].Response.FacetCounts {
+		facetCounts[facetCount.FieldName] = facetCount
+	}
+	for i, field := range refinedFields {
+		for _, facetCount := range results[i+1].Response.FacetCounts {
+			if facetCount.FieldName == field {
+				facetCounts[field] = facetCount
+			}
+		}
+	}
+
+	state := FacetState{
+		Response: results[0].Response,
+		FilterBy: mainOptions.filterBy(),
+	}
+	fields := append([]string{}, searchOptions.FacetBy...)
+	for _, field := range refinedFields {
+		if !containsString(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+	for _, field := range fields {
+		state.Facets = append(state.Facets, facetFieldState(field, facetCounts[field], refinements[field]))
+	}
+	return &state, nil
+}
+
+// refinedSearchOptions returns a copy of the search options filtered by
+// the refinements of every field but the excluded field. The values of
+// a field are combined with || and the fields with &&.
+func refinedSearchOptions(searchOptions *SearchOptions, refinements FacetRefinements, excludedField string) *SearchOptions {
+	opts := *searchOptions
+	var filters []Filter
+	if searchOptions.Filter != nil {
+		filters = append(filters, searchOptions.Filter)
+	}
+	fields := make([]string, 0, len(refinements))
+	for field := range refinements {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		values := refinements[field]
+		if field == excludedField || len(values) == 0 {
+			continue
+		}
+		filterValues := make([]interface{}, len(values))
+		for i, value := range values {
+			filterValues[i] = value
+		}
+		filters = append(filters, In(field, filterValues...))
+	}
+	if len(filters) > 0 {
+		opts.Filter = And(filters...)
+	}
+	return &opts
+}
+
+func facetFieldState(field string, facetCount FacetCount, selected []string) FacetFieldState {
+	state := FacetFieldState{FieldName: field, Stats: facetCount.Stats}
+	counted := make(map[string]bool, len(facetCount.Counts))
+	for _, count := range facetCount.Counts {
+		counted[count.Value] = true
+		state.Values = append(state.Values, FacetValueState{
+			FacetValueCount: count,
+			Selected:        containsString(selected, count.Value),
+		})
+	}
+	for _, value := range selected {
+		if !counted[value] {
+			counted[value] = true
+			state.Values = append(state.Values, FacetValueState{
+				FacetValueCount: FacetValueCount{Value: value},
+				Selected:        true,
+			})
+		}
+	}
+	return state
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
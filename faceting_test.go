@@ -0,0 +1,171 @@
+package typesense
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDisjunctiveFacetSearch(t *testing.T) {
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		var body struct {
+			Searches []map[string]string `json:"searches"`
+		}
+		json.NewDecoder(req.Body).Decode(&body)
+		if len(body.Searches) != 3 {
+			t.Fatalf("Expected %d searches, received %d", 3, len(body.Searches))
+		}
+		expected := []map[string]string{
+			{
+				"collection": "books",
+				"q":          "harry",
+				"query_by":   "title",
+				"facet_by":   "authors,year,language",
+				"filter_by":  "in_stock:=true && authors:=[`J.K. Rowling`] && year:=[1997,1998]",
+			},
+			{
+				"collection": "books",
+				"q":          "harry",
+				"query_by":   "title",
+				"facet_by":   "authors",
+				"filter_by":  "in_stock:=true && year:=[1997,1998]",
+				"per_page":   "0",
+			},
+			{
+				"collection": "books",
+				"q":          "harry",
+				"query_by":   "title",
+				"facet_by":   "year",
+				"filter_by":  "in_stock:=true && authors:=[`J.K. Rowling`]",
+				"per_page":   "0",
+			},
+		}
+		if !reflect.DeepEqual(body.Searches, expected) {
+			t.Errorf("Expected searches %v, received %v", expected, body.Searches)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: ioutil.NopCloser(strings.NewReader(`{"results": [
+				{
+					"found": 1,
+					"hits": [{"highlights": [], "document": {"id": "1"}}],
+					"facet_counts": [
+						{"field_name": "authors", "counts": [{"count": 1, "value": "J.K. Rowling"}]},
+						{"field_name": "year", "counts": [{"count": 1, "value": "1997"}]},
+						{"field_name": "language", "counts": [{"count": 1, "value": "en"}]}
+					]
+				},
+				{
+					"found": 3,
+					"hits": [],
+					"facet_counts": [
+						{"field_name": "authors", "counts": [{"count": 2, "value": "Mary GrandPré"}, {"count": 1, "value": "J.K. Rowling"}]}
+					]
+				},
+				{
+					"found": 2,
+					"hits": [],
+					"facet_counts": [
+						{"field_name": "year", "counts": [{"count": 1, "value": "1997"}, {"count": 1, "value": "2007"}]}
+					]
+				}
+			]}`)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	state, err := client.DisjunctiveFacetSearch(
+		"books",
+		&SearchOptions{
+			Query:   "harry",
+			QueryBy: []string{"title"},
+			FacetBy: []string{"authors", "year", "language"},
+			Filter:  Eq("in_stock", true),
+		},
+		FacetRefinements{
+			"authors": {"J.K. Rowling"},
+			"year":    {"1997", "1998"},
+		},
+	)
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if state.Response.Found != 1 {
+		t.Errorf("Expected the refined response, received %+v", state.Response)
+	}
+	if expected := "in_stock:=true && authors:=[`J.K. Rowling`] && year:=[1997,1998]"; state.FilterBy != expected {
+		t.Errorf("Expected filter_by %q, received %q", expected, state.FilterBy)
+	}
+	expected := []FacetFieldState{
+		{
+			FieldName: "authors",
+			Values: []FacetValueState{
+				{FacetValueCount: FacetValueCount{Count: 2, Value: "Mary GrandPré"}},
+				{FacetValueCount: FacetValueCount{Count: 1, Value: "J.K. Rowling"}, Selected: true},
+			},
+		},
+		{
+			FieldName: "year",
+			Values: []FacetValueState{
+				{FacetValueCount: FacetValueCount{Count: 1, Value: "1997"}, Selected: true},
+				{FacetValueCount: FacetValueCount{Count: 1, Value: "2007"}},
+				{FacetValueCount: FacetValueCount{Count: 0, Value: "1998"}, Selected: true},
+			},
+		},
+		{
+			FieldName: "language",
+			Values: []FacetValueState{
+				{FacetValueCount: FacetValueCount{Count: 1, Value: "en"}},
+			},
+		},
+	}
+	if !reflect.DeepEqual(state.Facets, expected) {
+		t.Errorf("Expected facets %+v, received %+v", expected, state.Facets)
+	}
+}
+
+func TestDisjunctiveFacetSearch_searchError(t *testing.T) {
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"results": [{"code": 404, "error": "Not found."}]}`)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	_, err := client.DisjunctiveFacetSearch("books", &SearchOptions{Query: "*", QueryBy: []string{"title"}}, nil)
+	if err != ErrNotFound {
+		t.Errorf("Expected to receive error %v, received %v", ErrNotFound, err)
+	}
+}
+
+func TestDisjunctiveFacetSearch_invalid(t *testing.T) {
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"results": [{"found": 0, "hits": []}]}`)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	if _, err := client.DisjunctiveFacetSearch("books", nil, nil); err != ErrQueryRequired {
+		t.Errorf("Expected to receive error %v, received %v", ErrQueryRequired, err)
+	}
+	_, err := client.DisjunctiveFacetSearch(
+		"books",
+		&SearchOptions{Query: "*", QueryBy: []string{"title"}, FacetBy: []string{"authors"}},
+		FacetRefinements{"authors": {"J.K. Rowling"}},
+	)
+	if err == nil {
+		t.Errorf("Expected an error for the missing facet search result")
+	}
+}
@@ -0,0 +1,295 @@
+package typesense
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Filter is a filter_by expression built with the filter constructors,
+// such as Eq, In, Range, And or Or, that renders to the Typesense
+// filter syntax with its values properly quoted.
+type Filter interface {
+	// String returns the filter in the Typesense filter_by syntax.
+	String() string
+
+	// negate returns the negation of the filter.
+	negate() Filter
+}
+
+// Raw creates a filter from an expression already in the Typesense
+// filter_by syntax, it is rendered as is.
+func Raw(expression string) Filter {
+	return rawFilter(expression)
+}
+
+// Eq matches documents whose field is exactly equal to value.
+func Eq(field string, value interface{}) Filter {
+	return comparisonFilter{field: field, operator: "=", value: value}
+}
+
+// NotEq matches documents whose field is not equal to value.
+func NotEq(field string, value interface{}) Filter {
+	return comparisonFilter{field: field, operator: "!=", value: value}
+}
+
+// Gt matches documents whose field is greater than value.
+func Gt(field string, value interface{}) Filter {
+	return comparisonFilter{field: field, operator: ">", value: value}
+}
+
+// Gte matches documents whose field is greater than or equal to value.
+func Gte(field string, value interface{}) Filter {
+	return comparisonFilter{field: field, operator: ">=", value: value}
+}
+
+// Lt matches documents whose field is less than value.
+func Lt(field string, value interface{}) Filter {
+	return comparisonFilter{field: field, operator: "<", value: value}
+}
+
+// Lte matches documents whose field is less than or equal to value.
+func Lte(field string, value interface{}) Filter {
+	return comparisonFilter{field: field, operator: "<=", value: value}
+}
+
+// In matches documents whose field is exactly equal to any of values.
+func In(field string, values ...interface{}) Filter {
+	return listFilter{field: field, operator: "=", values: values}
+}
+
+// NotIn matches documents whose field is not equal to any of values.
+func NotIn(field string, values ...interface{}) Filter {
+	return listFilter{field: field, operator: "!=", values: values}
+}
+
+// Range matches documents whose numeric field is between min and max,
+// both inclusive.
+func Range(field string, min, max interface{}) Filter {
+	return rangeFilter{field: field, min: min, max: max}
+}
+
+// GeoRadius matches documents whose geopoint field is within radius of
+// the point at lat and lng. Unit is the unit of radius, km or mi.
+func GeoRadius(field string, lat, lng, radius float64, unit string) Filter {
+	return geoRadiusFilter{field: field, lat: lat, lng: lng, radius: radius, unit: unit}
+}
+
+// GeoPolygon matches documents whose geopoint field is within the
+// polygon with the given vertices.
+func GeoPolygon(field string, vertices ...GeoPoint) Filter {
+	return geoPolygonFilter{field: field, vertices: vertices}
+}
+
+// And matches documents that match every filter.
+func And(filters ...Filter) Filter {
+	return logicalFilter{operator: "&&", filters: filters}
+}
+
+// Or matches documents that match any of the filters.
+func Or(filters ...Filter) Filter {
+	return logicalFilter{operator: "||", filters: filters}
+}
+
+// Not matches documents that do not match the filter. Comparisons and
+// lists are rendered with their negated operators and And and Or are
+// negated using De Morgan's laws. Other filters are rendered as
+// !(filter), which requires a Typesense version that supports it.
+func Not(filter Filter) Filter {
+	return filter.negate()
+}
+
+type rawFilter string
+
+func (f rawFilter) String() string {
+	return string(f)
+}
+
+func (f rawFilter) negate() Filter {
+	return notFilter{filter: f}
+}
+
+type comparisonFilter struct {
+	field    string
+	operator string
+	value    interface{}
+}
+
+var negatedOperators = map[string]string{
+	"=":  "!=",
+	"!=": "=",
+	">":  "<=",
+	">=": "<",
+	"<":  ">=",
+	"<=": ">",
+}
+
+func (f comparisonFilter) String() string {
+	return f.field + ":" + f.operator + formatFilterValue(f.value)
+}
+
+func (f comparisonFilter) negate() Filter {
+	f.operator = negatedOperators[f.operator]
+	return f
+}
+
+type listFilter struct {
+	field    string
+	operator string
+	values   []interface{}
+}
+
+func (f listFilter) String() string {
+	values := make([]string, len(f.values))
+	for i, value := range f.values {
+		values[i] = formatFilterValue(value)
+	}
+	return f.field + ":" + f.operator + "[" + strings.Join(values, ",") + "]"
+}
+
+func (f listFilter) negate() Filter {
+	f.operator = negatedOperators[f.operator]
+	return f
+}
+
+type rangeFilter struct {
+	field string
+	min   interface{}
+	max   interface{}
+}
+
+func (f rangeFilter) String() string {
+	return f.field + ":[" + formatFilterValue(f.min) + ".." + formatFilterValue(f.max) + "]"
+}
+
+func (f rangeFilter) negate() Filter {
+	return Or(Lt(f.field, f.min), Gt(f.field, f.max))
+}
+
+type geoRadiusFilter struct {
+	field    string
+	lat, lng float64
+	radius   float64
+	unit     string
+}
+
+func (f geoRadiusFilter) String() string {
+	return fmt.Sprintf(
+		"%s:(%s, %s, %s %s)",
+		f.field,
+		formatFilterFloat(f.lat),
+		formatFilterFloat(f.lng),
+		formatFilterFloat(f.radius),
+		f.unit,
+	)
+}
+
+func (f geoRadiusFilter) negate() Filter {
+	return notFilter{filter: f}
+}
+
+type geoPolygonFilter struct {
+	field    string
+	vertices []GeoPoint
+}
+
+func (f geoPolygonFilter) String() string {
+	coordinates := make([]string, 0, 2*len(f.vertices))
+	for _, vertex := range f.vertices {
+		coordinates = append(coordinates, formatFilterFloat(vertex.Lat), formatFilterFloat(vertex.Lng))
+	}
+	return f.field + ":(" + strings.Join(coordinates, ", ") + ")"
+}
+
+func (f geoPolygonFilter) negate() Filter {
+	return notFilter{filter: f}
+}
+
+type logicalFilter struct {
+	operator string
+	filters  []Filter
+}
+
+func (f logicalFilter) String() string {
+	expressions := make([]string, 0, len(f.filters))
+	for _, filter := range f.filters {
+		expression := filter.String()
+		if expression == "" {
+			continue
+		}
+		switch group := filter.(type) {
+		case logicalFilter:
+			if group.operator != f.operator && len(group.filters) > 1 {
+				expression = "(" + expression + ")"
+			}
+		case rawFilter:
+			if f.operator == "&&" && strings.Contains(expression, "||") {
+				expression = "(" + expression + ")"
+			}
+		}
+		expressions = append(expressions, expression)
+	}
+	return strings.Join(expressions, " "+f.operator+" ")
+}
+
+func (f logicalFilter) negate() Filter {
+	negated := logicalFilter{operator: "||", filters: make([]Filter, len(f.filters))}
+	if f.operator == "||" {
+		negated.operator = "&&"
+	}
+	for i, filter := range f.filters {
+		negated.filters[i] = filter.negate()
+	}
+	return negated
+}
+
+type notFilter struct {
+	filter Filter
+}
+
+func (f notFilter) String() string {
+	return "!(" + f.filter.String() + ")"
+}
+
+func (f notFilter) negate() Filter {
+	return f.filter
+}
+
+// formatFilterValue renders a filter value, strings are quoted when
+// they contain filter syntax characters.
+func formatFilterValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return filterValue(v)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return formatFilterFloat(v)
+	case fmt.Stringer:
+		return filterValue(v.String())
+	}
+	return fmt.Sprint(value)
+}
+
+func formatFilterFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// idsFilter builds a filter_by condition matching every document
+// whose id is in ids.
+func idsFilter(ids []string) string {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = filterValue(id)
+	}
+	return "id:[" + strings.Join(values, ",") + "]"
+}
+
+// filterValue quotes a filter_by value with backticks when it contains
+// characters that are part of the filter syntax.
+func filterValue(value string) string {
+	if value == "" || strings.ContainsAny(value, ",:[]()&|<>=!` ") {
+		return "`" + strings.ReplaceAll(value, "`", "\\`") + "`"
+	}
+	return value
+}
@@ -0,0 +1,63 @@
+package typesense
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestFilter_String(t *testing.T) {
+	tests := []struct {
+		filter   Filter
+		expected string
+	}{
+		{Eq("category", "shoes"), "category:=shoes"},
+		{Eq("brand", "Nike, Inc"), "brand:=`Nike, Inc`"},
+		{NotEq("in_stock", false), "in_stock:!=false"},
+		{In("tags", "a", "b c", 3), "tags:=[a,`b c`,3]"},
+		{NotIn("id", "1", "2"), "id:!=[1,2]"},
+		{Gt("price", 10), "price:>10"},
+		{Gte("price", 10.5), "price:>=10.5"},
+		{Lt("price", 20), "price:<20"},
+		{Lte("rating", float32(4.5)), "rating:<=4.5"},
+		{Range("price", 10, 20), "price:[10..20]"},
+		{GeoRadius("location", 48.8566, 2.3522, 5, "km"), "location:(48.8566, 2.3522, 5 km)"},
+		{
+			GeoPolygon("location", GeoPoint{48.87, 2.28}, GeoPoint{48.87, 2.35}, GeoPoint{48.84, 2.35}),
+			"location:(48.87, 2.28, 48.87, 2.35, 48.84, 2.35)",
+		},
+		{And(Eq("a", 1), Or(Eq("b", 2), Eq("c", 3))), "a:=1 && (b:=2 || c:=3)"},
+		{Or(And(Eq("a", 1), Eq("b", 2)), Eq("c", 3)), "(a:=1 && b:=2) || c:=3"},
+		{And(Eq("a", 1), And(Eq("b", 2), Eq("c", 3))), "a:=1 && b:=2 && c:=3"},
+		{And(Raw("a:1 || b:2"), Eq("c", 3)), "(a:1 || b:2) && c:=3"},
+		{Not(Eq("a", 1)), "a:!=1"},
+		{Not(Gt("a", 1)), "a:<=1"},
+		{Not(In("a", 1, 2)), "a:!=[1,2]"},
+		{Not(Range("a", 1, 2)), "a:<1 || a:>2"},
+		{Not(And(Eq("a", 1), Lt("b", 2))), "a:!=1 || b:>=2"},
+		{Not(GeoRadius("location", 1, 2, 3, "mi")), "!(location:(1, 2, 3 mi))"},
+		{Not(Not(Raw("a:1"))), "a:1"},
+	}
+	for _, test := range tests {
+		if filter := test.filter.String(); filter != test.expected {
+			t.Errorf("Expected filter %q, received %q", test.expected, filter)
+		}
+	}
+}
+
+func TestSearchOptions_filter(t *testing.T) {
+	opts := SearchOptions{
+		Query:    "query",
+		QueryBy:  []string{"name"},
+		FilterBy: []string{"age:>3"},
+		Filter:   Or(Eq("city", "São Paulo"), Eq("city", "Rio")),
+	}
+	form, err := opts.encodeForm()
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	values, _ := url.ParseQuery(form)
+	expected := "age:>3 && (city:=`São Paulo` || city:=Rio)"
+	if filterBy := values.Get("filter_by"); filterBy != expected {
+		t.Errorf("Expected filter_by %q, received %q", expected, filterBy)
+	}
+}
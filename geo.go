@@ -0,0 +1,31 @@
+package typesense
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// GeoPoint is the value of a geopoint field, it is encoded as the
+// [lat, lng] pair Typesense expects.
+type GeoPoint struct {
+	Lat float64
+	Lng float64
+}
+
+// MarshalJSON encodes the point as [lat, lng].
+func (p GeoPoint) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]float64{p.Lat, p.Lng})
+}
+
+// UnmarshalJSON decodes the point from [lat, lng].
+func (p *GeoPoint) UnmarshalJSON(data []byte) error {
+	var pair []float64
+	if err := json.Unmarshal(data, &pair); err != nil {
+		return err
+	}
+	if len(pair) != 2 {
+		return fmt.Errorf("typesense: geopoint must have 2 coordinates, has %d", len(pair))
+	}
+	p.Lat, p.Lng = pair[0], pair[1]
+	return nil
+}
@@ -0,0 +1,53 @@
+package typesense
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGeoPoint(t *testing.T) {
+	type store struct {
+		ID       string   `json:"id"`
+		Location GeoPoint `json:"location"`
+	}
+	data, err := json.Marshal(store{ID: "1", Location: GeoPoint{Lat: 48.8566, Lng: 2.3522}})
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if expected := `{"id":"1","location":[48.8566,2.3522]}`; string(data) != expected {
+		t.Errorf("Expected to marshal %s, received %s", expected, data)
+	}
+	var decoded store
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if decoded.Location != (GeoPoint{Lat: 48.8566, Lng: 2.3522}) {
+		t.Errorf("Expected to unmarshal the point, received %+v", decoded.Location)
+	}
+	if err := json.Unmarshal([]byte(`[1, 2, 3]`), &decoded.Location); err == nil {
+		t.Errorf("Expected to receive an error unmarshaling 3 coordinates")
+	}
+}
+
+func TestGeoPoint_encoder(t *testing.T) {
+	type store struct {
+		ID       int        `typesense:"id"`
+		Location GeoPoint   `json:"location"`
+		Branches []GeoPoint `json:"branches"`
+	}
+	encoder := DocumentEncoder{}
+	data, err := encoder.Encode(store{ID: 1, Location: GeoPoint{Lat: 1, Lng: 2}, Branches: []GeoPoint{{Lat: 3, Lng: 4}}})
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if expected := `{"branches":[[3,4]],"id":"1","location":[1,2]}`; string(data) != expected {
+		t.Errorf("Expected to encode %s, received %s", expected, data)
+	}
+	var decoded store
+	if err := encoder.Decode(data, &decoded); err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if decoded.Location != (GeoPoint{Lat: 1, Lng: 2}) || len(decoded.Branches) != 1 || decoded.Branches[0] != (GeoPoint{Lat: 3, Lng: 4}) {
+		t.Errorf("Expected to decode the points, received %+v", decoded)
+	}
+}
@@ -0,0 +1,181 @@
+package typesense
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+const (
+	defaultHighlightStartTag = "<mark>"
+	defaultHighlightEndTag   = "</mark>"
+
+	ansiReset = "\x1b[0m"
+
+	// ANSIBoldYellow is the default ANSI escape sequence of the matches
+	// rendered by an ANSI highlighter.
+	ANSIBoldYellow = "\x1b[1;33m"
+)
+
+// Highlighter renders the highlights of search hits, replacing the
+// tags the server marks the matches with by its own markers and
+// escaping the rest of the text.
+type Highlighter struct {
+	// StartTag and EndTag are the tags the server marks the matches
+	// with, they must match the HighlightStartTag and HighlightEndTag
+	// of the search. Default values are <mark> and </mark>.
+	StartTag string
+	EndTag   string
+
+	// MatchStart and MatchEnd are written around every match.
+	MatchStart string
+	MatchEnd   string
+
+	// Escape escapes the text, matches included. The text is written
+	// as is when it is nil.
+	Escape func(text string) string
+}
+
+// NewHTMLHighlighter creates a Highlighter that renders HTML, with the
+// text escaped and the matches between the matchStart and matchEnd
+// tags, such as <em> and </em>.
+func NewHTMLHighlighter(matchStart, matchEnd string) *Highlighter {
+	return &Highlighter{
+		MatchStart: matchStart,
+		MatchEnd:   matchEnd,
+		Escape:     html.EscapeString,
+	}
+}
+
+// NewANSIHighlighter creates a Highlighter that renders terminal text,
+// with the matches in the color of the ANSI escape sequence, such as
+// ANSIBoldYellow. Escape characters are removed from the text.
+func NewANSIHighlighter(color string) *Highlighter {
+	return &Highlighter{
+		MatchStart: color,
+		MatchEnd:   ansiReset,
+		Escape:     stripANSI,
+	}
+}
+
+// Render renders a snippet marked by the server.
+func (h *Highlighter) Render(snippet string) string {
+	startTag, endTag := h.tags()
+	var b strings.Builder
+	for {
+		start := strings.Index(snippet, startTag)
+		if start < 0 {
+			break
+		}
+		end := strings.Index(snippet[start+len(startTag):], endTag)
+		if end < 0 {
+			break
+		}
+		end += start + len(startTag)
+		b.WriteString(h.escape(snippet[:start]))
+		b.WriteString(h.MatchStart)
+		b.WriteString(h.escape(snippet[start+len(startTag) : end]))
+		b.WriteString(h.MatchEnd)
+		snippet = snippet[end+len(endTag):]
+	}
+	b.WriteString(h.escape(snippet))
+	return b.String()
+}
+
+// Field renders the highlight of the field of the hit. When the field
+// was not highlighted the value of the field in the document is
+// rendered without matches. The elements of an array field are rendered
+// as FieldIndex does and joined by commas, FieldIndex renders them one
+// by one.
+func (h *Highlighter) Field(hit *SearchResultHit, field string) string {
+	for _, highlight := range hit.Highlights {
+		if highlight.Field == field && highlight.Snippet != "" {
+			return h.Render(highlight.Snippet)
+		}
+	}
+	if snippet, ok := nestedSnippet(hit.Highlight[field]); ok {
+		return h.Render(snippet)
+	}
+	value := hit.Document[field]
+	if values, ok := value.([]interface{}); ok {
+		elements := make([]string, len(values))
+		for i := range values {
+			elements[i] = h.FieldIndex(hit, field, i)
+		}
+		return strings.Join(elements, ", ")
+	}
+	return h.value(value)
+}
+
+// FieldIndex renders the highlight of the element at index of the array
+// field of the hit. When the element was not highlighted its value in
+// the document is rendered without matches.
+func (h *Highlighter) FieldIndex(hit *SearchResultHit, field string, index int) string {
+	for _, highlight := range hit.Highlights {
+		if highlight.Field != field {
+			continue
+		}
+		for i, highlighted := range highlight.Indices {
+			if highlighted == index && i < len(highlight.Snippets) {
+				return h.Render(highlight.Snippets[i])
+			}
+		}
+	}
+	if highlights, ok := hit.Highlight[field].([]interface{}); ok && index >= 0 && index < len(highlights) {
+		if snippet, ok := nestedSnippet(highlights[index]); ok {
+			return h.Render(snippet)
+		}
+	}
+	if values, ok := hit.Document[field].([]interface{}); ok && index >= 0 && index < len(values) {
+		return h.value(values[index])
+	}
+	return ""
+}
+
+// nestedSnippet returns the snippet of a value of the nested highlight
+// object, it is only set for matched values.
+func nestedSnippet(highlight interface{}) (string, bool) {
+	object, ok := highlight.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	if tokens, ok := object["matched_tokens"].([]interface{}); ok && len(tokens) == 0 {
+		return "", false
+	}
+	snippet, ok := object["snippet"].(string)
+	return snippet, ok
+}
+
+func (h *Highlighter) value(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return h.escape(v)
+	}
+	return h.escape(fmt.Sprint(value))
+}
+
+func (h *Highlighter) escape(text string) string {
+	if h.Escape == nil {
+		return text
+	}
+	return h.Escape(text)
+}
+
+func (h *Highlighter) tags() (string, string) {
+	startTag, endTag := h.StartTag, h.EndTag
+	if startTag == "" {
+		startTag = defaultHighlightStartTag
+	}
+	if endTag == "" {
+		endTag = defaultHighlightEndTag
+	}
+	return startTag, endTag
+}
+
+// stripANSI removes the escape characters from text, so it cannot
+// inject escape sequences in the terminal.
+func stripANSI(text string) string {
+	return strings.ReplaceAll(text, "\x1b", "")
+}
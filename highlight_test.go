@@ -0,0 +1,101 @@
+package typesense
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const highlightHitTest = `
+	{
+		"document": {
+			"id": "1",
+			"title": "Tom & Jerry <3",
+			"tags": ["cat", "mouse", "cartoon"],
+			"year": 1940
+		},
+		"highlights": [
+			{"field": "title", "snippet": "<mark>Tom</mark> & Jerry <3"},
+			{"field": "tags", "snippets": ["<mark>cat</mark>", "<mark>car</mark>toon"], "indices": [0, 2]}
+		]
+	}
+`
+
+func TestHighlighter_Render(t *testing.T) {
+	tests := []struct {
+		highlighter *Highlighter
+		snippet     string
+		expected    string
+	}{
+		{NewHTMLHighlighter("<em>", "</em>"), "<mark>Tom</mark> & <b>Jerry</b>", "<em>Tom</em> &amp; &lt;b&gt;Jerry&lt;/b&gt;"},
+		{NewHTMLHighlighter("<em>", "</em>"), "no matches", "no matches"},
+		{NewHTMLHighlighter("<em>", "</em>"), "<mark>unclosed", "&lt;mark&gt;unclosed"},
+		{NewANSIHighlighter(ANSIBoldYellow), "<mark>Tom</mark>\x1b[2J", "\x1b[1;33mTom\x1b[0m[2J"},
+		{
+			&Highlighter{StartTag: "{{", EndTag: "}}", MatchStart: "*", MatchEnd: "*"},
+			"{{Tom}} and {{Jerry}}",
+			"*Tom* and *Jerry*",
+		},
+	}
+	for _, test := range tests {
+		if rendered := test.highlighter.Render(test.snippet); rendered != test.expected {
+			t.Errorf("Expected to render %q, received %q", test.expected, rendered)
+		}
+	}
+}
+
+func TestHighlighter_Field(t *testing.T) {
+	var hit SearchResultHit
+	if err := json.Unmarshal([]byte(highlightHitTest), &hit); err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	highlighter := NewHTMLHighlighter("<em>", "</em>")
+	tests := []struct {
+		rendered string
+		expected string
+	}{
+		{highlighter.Field(&hit, "title"), "<em>Tom</em> &amp; Jerry &lt;3"},
+		{highlighter.Field(&hit, "year"), "1940"},
+		{highlighter.Field(&hit, "missing"), ""},
+		{highlighter.Field(&hit, "tags"), "<em>cat</em>, mouse, <em>car</em>toon"},
+		{highlighter.FieldIndex(&hit, "tags", 0), "<em>cat</em>"},
+		{highlighter.FieldIndex(&hit, "tags", 1), "mouse"},
+		{highlighter.FieldIndex(&hit, "tags", 2), "<em>car</em>toon"},
+		{highlighter.FieldIndex(&hit, "tags", 3), ""},
+	}
+	for _, test := range tests {
+		if test.rendered != test.expected {
+			t.Errorf("Expected to render %q, received %q", test.expected, test.rendered)
+		}
+	}
+}
+
+func TestHighlighter_nestedHighlight(t *testing.T) {
+	var hit SearchResultHit
+	err := json.Unmarshal([]byte(`{
+		"document": {"title": "Tom & Jerry", "tags": ["cat", "mouse"]},
+		"highlights": [],
+		"highlight": {
+			"title": {"snippet": "<mark>Tom</mark> & Jerry", "matched_tokens": ["Tom"]},
+			"tags": [
+				{"snippet": "cat", "matched_tokens": []},
+				{"snippet": "<mark>mouse</mark>", "matched_tokens": ["mouse"]}
+			]
+		}
+	}`), &hit)
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	highlighter := NewHTMLHighlighter("<em>", "</em>")
+	if rendered := highlighter.Field(&hit, "title"); rendered != "<em>Tom</em> &amp; Jerry" {
+		t.Errorf("Expected to render the nested highlight, received %q", rendered)
+	}
+	if rendered := highlighter.FieldIndex(&hit, "tags", 1); rendered != "<em>mouse</em>" {
+		t.Errorf("Expected to render the nested highlight, received %q", rendered)
+	}
+	if rendered := highlighter.FieldIndex(&hit, "tags", 0); rendered != "cat" {
+		t.Errorf("Expected to render the document value, received %q", rendered)
+	}
+	if rendered := highlighter.Field(&hit, "tags"); rendered != "cat, <em>mouse</em>" {
+		t.Errorf("Expected to render the array elements, received %q", rendered)
+	}
+}
@@ -0,0 +1,420 @@
+package typesense
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// ImportActionCreate creates new documents, failing for documents
+	// with an id that already exists in the collection.
+	ImportActionCreate = "create"
+
+	// ImportActionUpsert creates new documents or replaces existing
+	// documents with the same id.
+	ImportActionUpsert = "upsert"
+
+	// ImportActionUpdate updates fields of existing documents, failing
+	// for documents that do not exist in the collection.
+	ImportActionUpdate = "update"
+)
+
+const (
+	defaultImportBatchSize     = 100
+	defaultImportRetryInterval = 100 * time.Millisecond
+	maxImportRetryInterval     = 30 * time.Second
+)
+
+// ImportOptions are the options used to bulk import documents
+// into a collection.
+type ImportOptions struct {
+	// Action is the import action: create, upsert or update.
+	// Default value is create.
+	Action string
+
+	// BatchSize is the maximum number of documents sent on each
+	// import request. Default value is 100.
+	BatchSize int
+
+	// MaxRetries is the maximum number of times a document that failed
+	// with a retryable error is imported again, along with the later
+	// documents of the batch with the same id. Import requests that fail
+	// with a 429 or 5xx status or a network error are retried as well.
+	// Default value is 0.
+	MaxRetries int
+
+	// RetryInterval is the time to wait before retrying the failed
+	// documents, it doubles on every attempt up to 30 seconds, or up to
+	// RetryInterval if it is longer. Default value is 100 milliseconds.
+	RetryInterval time.Duration
+
+	// DeadLetter receives every document that could not be imported.
+	DeadLetter DeadLetterSink
+
+	// Progress receives the progress of the import after every batch.
+	Progress ProgressObserver
+
+	// ChangeDetector skips the documents that did not change since their
+	// last successful import. Default value is the detector of the
+	// client, see EnableChangeDetection.
+	ChangeDetector *ChangeDetector
+}
+
+// ImportResult is the result of importing a single document, as
+// returned by Typesense for every line of the import.
+type ImportResult struct {
+	Success  bool   `json:"success"`
+	Error    string `json:"error,omitempty"`
+	Code     int    `json:"code,omitempty"`
+	Document string `json:"document,omitempty"`
+
+	// Skipped is true when the document was not sent because it did
+	// not change since its last import.
+	Skipped bool `json:"-"`
+
+	// err is the error of the import request when the document failed
+	// because the whole request failed.
+	err error
+}
+
+// IsRetryable reports whether the document failed because of a
+// transient server error and can be imported again.
+func (r ImportResult) IsRetryable() bool {
+	return !r.Success && (r.Code == http.StatusTooManyRequests || r.Code >= http.StatusInternalServerError)
+}
+
+// ImportReport summarizes a bulk import. Results are in the same
+// order as the imported documents.
+type ImportReport struct {
+	NumImported int
+	NumFailed   int
+	NumSkipped  int
+	Results     []ImportResult
+}
+
+// ImportDocuments imports the documents into the collection using the
+// Typesense import endpoint. Documents that fail are reported in the
+// returned ImportReport, an error is only returned when a whole
+// request fails. The report is then returned along with the error, with
+// the documents that were not imported reported as failed and sent to
+// the dead letter sink.
+func (c *Client) ImportDocuments(collectionName string, documents []interface{}, opts *ImportOptions) (*ImportReport, error) {
+	if opts == nil {
+		opts = &ImportOptions{}
+	}
+	return c.importDocuments(collectionName, documents, opts, newProgressTracker(opts.Progress, len(documents)))
+}
+
+func (c *Client) importDocuments(collectionName string, documents []interface{}, opts *ImportOptions, tracker *progressTracker) (*ImportReport, error) {
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultImportBatchSize
+	}
+	report := ImportReport{Results: make([]ImportResult, 0, len(documents))}
+	for start := 0; start < len(documents); start += batchSize {
+		end := start + batchSize
+		if end > len(documents) {
+			end = len(documents)
+		}
+		lines, err := c.marshalImportLines(documents[start:end])
+		if err != nil {
+			report.add(failUnsentLines(c.unsentLines(documents[start:]), err, opts, tracker))
+			return &report, err
+		}
+		results, err := c.importBatch(collectionName, lines, opts, tracker)
+		report.add(results)
+		if err != nil {
+			report.add(failUnsentLines(c.unsentLines(documents[start+len(results):]), err, opts, tracker))
+			return &report, err
+		}
+	}
+	return &report, nil
+}
+
+// importBatch imports the lines, retrying the lines that failed with a
+// retryable error and sending the lines that still fail to the dead
+// letter sink. Lines that are invalid for the collection schema are not
+// sent when document validation is enabled, neither are the lines that
+// did not change since their last import when a change detector is set,
+// unless an earlier line of the batch with the same id is sent, as the
+// stored hash is then stale. If an import request fails, the lines it sent are failed with its
+// error and the results are returned along with the error.
+func (c *Client) importBatch(collectionName string, lines [][]byte, opts *ImportOptions, tracker *progressTracker) ([]ImportResult, error) {
+	results := make([]ImportResult, len(lines))
+	attempts := make([]int, len(lines))
+	ids := make([]string, len(lines))
+	hashes := make([]string, len(lines))
+	pending := make([]int, 0, len(lines))
+	detector := c.currentChangeDetector(opts.ChangeDetector)
+	pendingIDs := make(map[string]bool)
+	for i, line := range lines {
+		if err := c.validateDocument(collectionName, opts.Action, line); err != nil {
+			results[i] = ImportResult{Error: err.Error(), Code: http.StatusBadRequest}
+			continue
+		}
+		if detector != nil {
+			id, hash, changed, err := detector.changed(collectionName, line)
+			if err != nil {
+				return nil, err
+			}
+			if !changed && !pendingIDs[id] {
+				results[i] = ImportResult{Success: true, Skipped: true}
+				continue
+			}
+			if id != "" {
+				pendingIDs[id] = true
+			}
+			ids[i], hashes[i] = id, hash
+		}
+		pending = append(pending, i)
+	}
+	retryInterval := opts.RetryInterval
+	if retryInterval <= 0 {
+		retryInterval = defaultImportRetryInterval
+	}
+	var requestErr error
+	for attempt := 0; len(pending) > 0; attempt++ {
+		if attempt > 0 {
+			time.Sleep(importRetryDelay(retryInterval, attempt))
+		}
+		pendingLines := make([][]byte, len(pending))
+		for j, i := range pending {
+			pendingLines[j] = lines[i]
+			tracker.addBytes(int64(len(lines[i]) + 1))
+		}
+		pendingResults, err := c.importLines(collectionName, opts.Action, pendingLines)
+		if err != nil {
+			var httpErr HTTPError
+			errors.As(err, &httpErr)
+			for _, i := range pending {
+				results[i] = ImportResult{Error: err.Error(), Code: httpErr.Status, err: err}
+				attempts[i]++
+			}
+			if !isRetryableRequestError(err) || attempt >= opts.MaxRetries {
+				requestErr = err
+				break
+			}
+			continue
+		}
+		for j, i := range pending {
+			results[i] = pendingResults[j]
+			attempts[i]++
+			if results[i].Success && detector != nil {
+				if err := detector.record(collectionName, ids[i], hashes[i]); err != nil {
+					return nil, err
+				}
+			}
+		}
+		pending = retryLines(lines, pending, results)
+		if attempt >= opts.MaxRetries {
+			break
+		}
+	}
+	failed := 0
+	for _, result := range results {
+		if !result.Success {
+			failed++
+		}
+	}
+	tracker.addDocuments(len(results), failed)
+	if opts.DeadLetter != nil {
+		for i, result := range results {
+			if result.Success {
+				continue
+			}
+			err := opts.DeadLetter.WriteDeadLetter(DeadLetter{
+				Document: json.RawMessage(lines[i]),
+				Error:    result.Error,
+				Code:     result.Code,
+				Attempts: attempts[i],
+			})
+			if err != nil {
+				return results, err
+			}
+		}
+	}
+	return results, requestErr
+}
+
+// importRetryDelay returns the time to wait before the attempt, the
+// interval doubled on every attempt after the first retry, capped at
+// the longest of maxImportRetryInterval and the interval.
+func importRetryDelay(interval time.Duration, attempt int) time.Duration {
+	limit := maxImportRetryInterval
+	if interval > limit {
+		limit = interval
+	}
+	delay := interval
+	for i := 1; i < attempt && delay < limit; i++ {
+		delay *= 2
+	}
+	if delay > limit {
+		delay = limit
+	}
+	return delay
+}
+
+// isRetryableRequestError reports whether a whole import request failed
+// because of a transient server or network error.
+func isRetryableRequestError(err error) bool {
+	var httpErr HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Status == http.StatusTooManyRequests || httpErr.Status >= http.StatusInternalServerError
+	}
+	var urlErr *url.Error
+	var netErr net.Error
+	return errors.As(err, &urlErr) || errors.As(err, &netErr)
+}
+
+// retryLines returns the pending lines to import again: the lines that
+// failed with a retryable error and every line after them with the id
+// of a line imported again, so a stale version of a document is never
+// applied after a newer one that succeeded on the previous attempt.
+func retryLines(lines [][]byte, pending []int, results []ImportResult) []int {
+	var retry []int
+	retryIDs := make(map[string]bool)
+	for _, i := range pending {
+		id, hasID := lineDocumentID(lines[i])
+		if !results[i].IsRetryable() && !(hasID && retryIDs[id]) {
+			continue
+		}
+		retry = append(retry, i)
+		if hasID {
+			retryIDs[id] = true
+		}
+	}
+	return retry
+}
+
+// failUnsentLines fails the lines that were not sent because of err,
+// sending them to the dead letter sink. Errors of the sink are ignored,
+// err is reported instead.
+func failUnsentLines(lines [][]byte, err error, opts *ImportOptions, tracker *progressTracker) []ImportResult {
+	results := make([]ImportResult, len(lines))
+	for i, line := range lines {
+		results[i] = ImportResult{Error: err.Error(), err: err}
+		if opts.DeadLetter != nil {
+			opts.DeadLetter.WriteDeadLetter(DeadLetter{
+				Document: json.RawMessage(line),
+				Error:    err.Error(),
+			})
+		}
+	}
+	tracker.addDocuments(len(lines), len(lines))
+	return results
+}
+
+// unsentLines encodes the documents that were not sent, the documents
+// that cannot be encoded are null.
+func (c *Client) unsentLines(documents []interface{}) [][]byte {
+	lines := make([][]byte, len(documents))
+	for i, document := range documents {
+		if line, err := c.marshalDocument(document); err == nil {
+			lines[i] = line
+		} else {
+			lines[i] = []byte("null")
+		}
+	}
+	return lines
+}
+
+func (r *ImportReport) add(results []ImportResult) {
+	for _, result := range results {
+		if result.Skipped {
+			r.NumSkipped++
+		} else if result.Success {
+			r.NumImported++
+		} else {
+			r.NumFailed++
+		}
+	}
+	r.Results = append(r.Results, results...)
+}
+
+func (c *Client) marshalImportLines(documents []interface{}) ([][]byte, error) {
+	lines := make([][]byte, len(documents))
+	for i, document := range documents {
+		line, err := c.marshalDocument(document)
+		if err != nil {
+			return nil, err
+		}
+		lines[i] = line
+	}
+	return lines, nil
+}
+
+// importLines sends the JSONL lines to the import endpoint and returns
+// the result of every line.
+func (c *Client) importLines(collectionName, action string, lines [][]byte) ([]ImportResult, error) {
+	defer c.invalidateSearchCache(collectionName)
+	if action == "" {
+		action = ImportActionCreate
+	}
+	query := url.Values{}
+	query.Set("action", action)
+	method := http.MethodPost
+	url := fmt.Sprintf(
+		"%s://%s:%s/%s/%s/documents/import?%s",
+		c.masterNode.Protocol,
+		c.masterNode.Host,
+		c.masterNode.Port,
+		collectionsEndpoint,
+		collectionName,
+		query.Encode(),
+	)
+	body := bytes.Join(lines, []byte("\n"))
+	resp, err := c.apiCall(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrCollectionNotFound
+	} else if resp.StatusCode == http.StatusUnauthorized {
+		return nil, ErrUnauthorized
+	} else if resp.StatusCode == http.StatusBadRequest {
+		var apiErr APIError
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil {
+			apiErr.Message = "status bad request"
+		}
+		return nil, apiErr
+	} else if resp.StatusCode != http.StatusOK {
+		responseBody, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, HTTPError{
+			Status:       resp.StatusCode,
+			ResponseBody: responseBody,
+		}
+	}
+	results := make([]ImportResult, 0, len(lines))
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var result ImportResult
+		if err := json.Unmarshal(line, &result); err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(results) != len(lines) {
+		return nil, fmt.Errorf("import returned %d results for %d documents", len(results), len(lines))
+	}
+	return results, nil
+}
@@ -0,0 +1,73 @@
+package typesense
+
+import (
+	"bufio"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestImportDocuments(t *testing.T) {
+	var requests int
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		requests++
+		if action := req.URL.Query().Get("action"); action != ImportActionUpsert {
+			t.Errorf("Expected action %q, received %q", ImportActionUpsert, action)
+		}
+		var lines []string
+		scanner := bufio.NewScanner(req.Body)
+		for scanner.Scan() {
+			if strings.Contains(scanner.Text(), `"field2":0`) {
+				lines = append(lines, `{"success": false, "error": "field2 must be positive", "code": 400}`)
+			} else {
+				lines = append(lines, `{"success": true}`)
+			}
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(strings.Join(lines, "\n"))),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	documents := []interface{}{
+		testDocument,
+		testDocumentStruct{Field1: "invalid"},
+		testDocument,
+	}
+	report, err := client.ImportDocuments(collectionNameTest, documents, &ImportOptions{
+		Action:    ImportActionUpsert,
+		BatchSize: 2,
+	})
+	if err != nil {
+		t.Errorf("Expected to receive no errors, received %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected to send %d requests, sent %d", 2, requests)
+	}
+	if report.NumImported != 2 || report.NumFailed != 1 {
+		t.Errorf("Expected 2 imported and 1 failed documents, received %d and %d", report.NumImported, report.NumFailed)
+	}
+	if report.Results[1].Success || report.Results[1].Code != http.StatusBadRequest {
+		t.Errorf("Expected the second document to fail, received %+v", report.Results[1])
+	}
+}
+
+func TestImportDocuments_collectionNotFound(t *testing.T) {
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"message": "collection not found"}`)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	if _, err := client.ImportDocuments(collectionNameTest, []interface{}{testDocument}, nil); err != ErrCollectionNotFound {
+		t.Errorf("Expected to receive error %v, received %v", ErrCollectionNotFound, err)
+	}
+}
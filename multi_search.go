@@ -0,0 +1,237 @@
+package typesense
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+const multiSearchEndpoint = "multi_search"
+
+// MultiSearchRequest is a single search of a multi search.
+type MultiSearchRequest struct {
+	// Collection is the name of the collection to search.
+	Collection string
+
+	// Options are the options of this search, they override the
+	// common options of the multi search.
+	Options *SearchOptions
+}
+
+// MultiSearchResult is the result of a single search of a multi
+// search, either its response or the error it failed with.
+type MultiSearchResult struct {
+	Response *SearchResponse
+	Err      error
+}
+
+// multiSearchError is the representation of a failed search in a
+// multi search response.
+type multiSearchError struct {
+	Code  int    `json:"code"`
+	Error string `json:"error"`
+}
+
+// MultiSearch runs the searches in a single request. The common options
+// are applied to every search and may be overridden by the options of
+// each search, so the query and query by fields may be given by either.
+// The results are in the same order as the searches, a failed search
+// does not fail the other searches.
+func (c *Client) MultiSearch(common *SearchOptions, searches []MultiSearchRequest) ([]MultiSearchResult, error) {
+	var multiSearchResponse struct {
+		Results []json.RawMessage `json:"results"`
+	}
+	if err := c.multiSearch(context.Background(), common, searches, false, &multiSearchResponse); err != nil {
+		return nil, err
+	}
+	if len(multiSearchResponse.Results) != len(searches) {
+		return nil, fmt.Errorf("typesense: multi search returned %d results for %d searches", len(multiSearchResponse.Results), len(searches))
+	}
+	results := make([]MultiSearchResult, len(multiSearchResponse.Results))
+	for i, rawResult := range multiSearchResponse.Results {
+		var searchErr multiSearchError
+		if err := json.Unmarshal(rawResult, &searchErr); err != nil {
+			results[i].Err = err
+			continue
+		}
+		if searchErr.Error != "" {
+			results[i].Err = searchErr.err()
+			continue
+		}
+		var searchResponse SearchResponse
+		if err := json.Unmarshal(rawResult, &searchResponse); err != nil {
+			results[i].Err = err
+			continue
+		}
+		searchResponse.setEncoder(c.encoder)
+		results[i].Response = &searchResponse
+	}
+	return results, nil
+}
+
+// MultiSearchUnion runs the searches in a single request, as MultiSearch
+// does, and merges their hits into a single response.
+func (c *Client) MultiSearchUnion(common *SearchOptions, searches []MultiSearchRequest) (*SearchResponse, error) {
+	var searchResponse SearchResponse
+	if err := c.multiSearch(context.Background(), common, searches, true, &searchResponse); err != nil {
+		return nil, err
+	}
+	searchResponse.setEncoder(c.encoder)
+	return &searchResponse, nil
+}
+
+// singleMultiSearch runs a single search through the multi search
+// endpoint, so its options are sent in the request body, and returns
+// its response.
+func (c *Client) singleMultiSearch(ctx context.Context, collectionName string, searchOptions *SearchOptions) ([]byte, error) {
+	var multiSearchResponse struct {
+		Results []json.RawMessage `json:"results"`
+	}
+	searches := []MultiSearchRequest{{Collection: collectionName, Options: searchOptions}}
+	if err := c.multiSearch(ctx, nil, searches, false, &multiSearchResponse); err != nil {
+		return nil, err
+	}
+	if len(multiSearchResponse.Results) != 1 {
+		return nil, fmt.Errorf("typesense: multi search returned %d results for 1 search", len(multiSearchResponse.Results))
+	}
+	var searchErr multiSearchError
+	if err := json.Unmarshal(multiSearchResponse.Results[0], &searchErr); err != nil {
+		return nil, err
+	}
+	if searchErr.Error != "" {
+		return nil, searchErr.err()
+	}
+	return multiSearchResponse.Results[0], nil
+}
+
+// validateMultiSearch validates the common options and the options of
+// every search against the searched collection if search validation is
+// enabled. The paths of the violations are prefixed by the search,
+// e.g. searches[1].facet_by[0].
+func (c *Client) validateMultiSearch(common *SearchOptions, searches []MultiSearchRequest) error {
+	var errs ValidationErrors
+	for i, search := range searches {
+		for _, searchOptions := range []*SearchOptions{common, search.Options} {
+			if searchOptions == nil {
+				continue
+			}
+			err := c.validateSearch(search.Collection, searchOptions)
+			if err == nil {
+				continue
+			}
+			validationErrs, ok := err.(ValidationErrors)
+			if !ok {
+				return err
+			}
+			for _, validationErr := range validationErrs {
+				validationErr.Path = fmt.Sprintf("searches[%d].%s", i, validationErr.Path)
+				errs = append(errs, validationErr)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// multiSearchParams encodes the parameters of every search of a multi
+// search, including its collection.
+func multiSearchParams(searches []MultiSearchRequest) ([]map[string]string, error) {
+	params := make([]map[string]string, len(searches))
+	for i, search := range searches {
+		params[i] = map[string]string{"collection": search.Collection}
+		if search.Options != nil {
+			data, err := search.Options.encodeValues()
+			if err != nil {
+				return nil, err
+			}
+			for key := range data {
+				params[i][key] = data.Get(key)
+			}
+		}
+	}
+	return params, nil
+}
+
+func (e multiSearchError) err() error {
+	switch e.Code {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	}
+	return APIError{Message: e.Error}
+}
+
+// multiSearch sends the searches to the multi search endpoint and
+// decodes the response into v.
+func (c *Client) multiSearch(ctx context.Context, common *SearchOptions, searches []MultiSearchRequest, union bool, v interface{}) error {
+	var commonParams string
+	if common != nil {
+		data, err := common.encodeValues()
+		if err != nil {
+			return err
+		}
+		commonParams = data.Encode()
+	}
+	type multiSearchBody struct {
+		Union    bool                `json:"union,omitempty"`
+		Searches []map[string]string `json:"searches"`
+	}
+	if err := c.validateMultiSearch(common, searches); err != nil {
+		return err
+	}
+	params, err := multiSearchParams(searches)
+	if err != nil {
+		return err
+	}
+	body := multiSearchBody{
+		Union:    union,
+		Searches: params,
+	}
+	bodyJSON, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	method := http.MethodPost
+	url := fmt.Sprintf(
+		"%s://%s:%s/%s",
+		c.masterNode.Protocol,
+		c.masterNode.Host,
+		c.masterNode.Port,
+		multiSearchEndpoint,
+	)
+	if commonParams != "" {
+		url += "?" + commonParams
+	}
+	resp, err := c.apiCallContext(ctx, method, url, bodyJSON)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized {
+		return ErrUnauthorized
+	} else if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	} else if resp.StatusCode == http.StatusBadRequest {
+		var apiErr APIError
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil {
+			apiErr.Message = "status bad request"
+		}
+		return apiErr
+	} else if resp.StatusCode != http.StatusOK {
+		responseBody, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		return HTTPError{
+			Status:       resp.StatusCode,
+			ResponseBody: responseBody,
+		}
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,140 @@
+package typesense
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestMultiSearch(t *testing.T) {
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodPost || req.URL.Path != "/multi_search" {
+			t.Errorf("Expected POST /multi_search, received %s %s", req.Method, req.URL.Path)
+		}
+		if queryBy := req.URL.Query().Get("query_by"); queryBy != "title" {
+			t.Errorf("Expected common query_by %q, received %q", "title", queryBy)
+		}
+		var body struct {
+			Union    bool                `json:"union"`
+			Searches []map[string]string `json:"searches"`
+		}
+		json.NewDecoder(req.Body).Decode(&body)
+		if body.Union || len(body.Searches) != 2 {
+			t.Fatalf("Expected 2 searches without union, received %+v", body)
+		}
+		if body.Searches[0]["collection"] != "books" || body.Searches[0]["q"] != "harry" {
+			t.Errorf("Expected the first search on books, received %v", body.Searches[0])
+		}
+		if body.Searches[1]["filter_by"] != "year:>2000" {
+			t.Errorf("Expected the second search to be filtered, received %v", body.Searches[1])
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: ioutil.NopCloser(strings.NewReader(
+				`{"results": [` + searchResultTest + `, {"code": 404, "error": "Not found."}]}`,
+			)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	results, err := client.MultiSearch(
+		&SearchOptions{QueryBy: []string{"title"}},
+		[]MultiSearchRequest{
+			{Collection: "books", Options: &SearchOptions{Query: "harry"}},
+			{Collection: "missing", Options: &SearchOptions{Query: "potter", Filter: Gt("year", 2000)}},
+		},
+	)
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected %d results, received %d", 2, len(results))
+	}
+	if results[0].Err != nil || results[0].Response.Found != 62 {
+		t.Errorf("Expected the first search to succeed, received %+v", results[0])
+	}
+	if results[1].Err != ErrNotFound {
+		t.Errorf("Expected to receive error %v, received %v", ErrNotFound, results[1].Err)
+	}
+}
+
+func TestMultiSearchUnion(t *testing.T) {
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		var body struct {
+			Union bool `json:"union"`
+		}
+		json.NewDecoder(req.Body).Decode(&body)
+		if !body.Union {
+			t.Errorf("Expected the union to be requested")
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(searchResultTest)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	searchResp, err := client.MultiSearchUnion(nil, []MultiSearchRequest{
+		{Collection: "books", Options: &SearchOptions{Query: "harry", QueryBy: []string{"title"}}},
+		{Collection: "movies", Options: &SearchOptions{Query: "harry", QueryBy: []string{"title"}}},
+	})
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if len(searchResp.Hits) != 1 {
+		t.Errorf("Expected to get %d hit, got %d", 1, len(searchResp.Hits))
+	}
+}
+
+func TestMultiSearch_missingResults(t *testing.T) {
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"results": [{"found": 0, "hits": []}]}`)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	results, err := client.MultiSearch(&SearchOptions{Query: "*", QueryBy: []string{"title"}}, []MultiSearchRequest{
+		{Collection: "books"},
+		{Collection: "authors"},
+	})
+	if err == nil || results != nil {
+		t.Errorf("Expected an error for the missing result, received %v and %v", results, err)
+	}
+}
+
+func TestMultiSearch_hitDocuments(t *testing.T) {
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"results": [` + searchResultTest + `]}`)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	results, err := client.MultiSearch(&SearchOptions{Query: "harry", QueryBy: []string{"title"}}, []MultiSearchRequest{
+		{Collection: "books"},
+	})
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	hit := results[0].Response.Hits[0]
+	if hit.Document["id"] != "2" {
+		t.Errorf("Expected the document map of the hit to be decoded, received %v", hit.Document)
+	}
+	var book searchHitBook
+	if err := hit.Decode(&book); err != nil || book.ID != "2" {
+		t.Errorf("Expected the hit to be decoded, received %+v and %v", book, err)
+	}
+}
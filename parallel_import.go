@@ -0,0 +1,128 @@
+package typesense
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"sync"
+)
+
+// ImportDocumentsParallel imports the documents into the collection
+// using workers concurrent import workers. Documents are partitioned
+// across the workers by hashing their id, so every operation on the
+// same document is imported by the same worker in the order it appears
+// in documents, while throughput scales across different ids. Documents
+// without an id are spread across all workers.
+//
+// The results of every worker are merged into a single ImportReport in
+// the order of documents. If an import request fails, the workers stop,
+// the documents that were not imported are reported as failed and sent
+// to the dead letter sink, and the first error is returned.
+func (c *Client) ImportDocumentsParallel(collectionName string, documents []interface{}, workers int, opts *ImportOptions) (*ImportReport, error) {
+	if opts == nil {
+		opts = &ImportOptions{}
+	}
+	if workers <= 0 {
+		workers = 1
+	}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultImportBatchSize
+	}
+	tracker := newProgressTracker(opts.Progress, len(documents))
+	lines, err := c.marshalImportLines(documents)
+	if err != nil {
+		report := ImportReport{}
+		report.add(failUnsentLines(c.unsentLines(documents), err, opts, tracker))
+		return &report, err
+	}
+	partitions := make([][]int, workers)
+	for i, line := range lines {
+		worker := i % workers
+		if id, ok := lineDocumentID(line); ok {
+			hash := fnv.New32a()
+			hash.Write([]byte(id))
+			worker = int(hash.Sum32() % uint32(workers))
+		}
+		partitions[worker] = append(partitions[worker], i)
+	}
+
+	results := make([]ImportResult, len(lines))
+	sent := make([]bool, len(lines))
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	for _, partition := range partitions {
+		wg.Add(1)
+		go func(partition []int) {
+			defer wg.Done()
+			for start := 0; start < len(partition); start += batchSize {
+				mu.Lock()
+				failed := firstErr != nil
+				mu.Unlock()
+				if failed {
+					return
+				}
+				end := start + batchSize
+				if end > len(partition) {
+					end = len(partition)
+				}
+				batch := make([][]byte, end-start)
+				for j, i := range partition[start:end] {
+					batch[j] = lines[i]
+				}
+				batchResults, err := c.importBatch(collectionName, batch, opts, tracker)
+				if batchResults != nil {
+					for j, i := range partition[start:end] {
+						results[i] = batchResults[j]
+						sent[i] = true
+					}
+				}
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+					return
+				}
+			}
+		}(partition)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		var unsent []int
+		for i := range results {
+			if !sent[i] {
+				unsent = append(unsent, i)
+			}
+		}
+		unsentLines := make([][]byte, len(unsent))
+		for j, i := range unsent {
+			unsentLines[j] = lines[i]
+		}
+		for j, result := range failUnsentLines(unsentLines, firstErr, opts, tracker) {
+			results[unsent[j]] = result
+		}
+	}
+	report := ImportReport{Results: make([]ImportResult, 0, len(results))}
+	report.add(results)
+	return &report, firstErr
+}
+
+// lineDocumentID returns the id of the JSON encoded document.
+func lineDocumentID(line []byte) (string, bool) {
+	var document struct {
+		ID json.RawMessage `json:"id"`
+	}
+	if err := json.Unmarshal(line, &document); err != nil || len(document.ID) == 0 {
+		return "", false
+	}
+	var id string
+	if err := json.Unmarshal(document.ID, &id); err != nil {
+		return string(document.ID), true
+	}
+	return id, true
+}
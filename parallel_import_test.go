@@ -0,0 +1,185 @@
+package typesense
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestImportDocumentsParallel(t *testing.T) {
+	type versionedDocument struct {
+		ID      string `json:"id"`
+		Version int    `json:"version"`
+	}
+	var (
+		mu       sync.Mutex
+		requests int
+		versions = make(map[string][]int)
+	)
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		var lines []string
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		scanner := bufio.NewScanner(req.Body)
+		for scanner.Scan() {
+			var document versionedDocument
+			json.Unmarshal(scanner.Bytes(), &document)
+			versions[document.ID] = append(versions[document.ID], document.Version)
+			lines = append(lines, `{"success": true}`)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(strings.Join(lines, "\n"))),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	var documents []interface{}
+	for version := 0; version < 10; version++ {
+		for id := 0; id < 20; id++ {
+			documents = append(documents, versionedDocument{ID: fmt.Sprint(id), Version: version})
+		}
+	}
+	report, err := client.ImportDocumentsParallel(collectionNameTest, documents, 4, &ImportOptions{
+		Action:    ImportActionUpsert,
+		BatchSize: 7,
+	})
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if report.NumImported != len(documents) || len(report.Results) != len(documents) {
+		t.Errorf("Expected to import %d documents, imported %d", len(documents), report.NumImported)
+	}
+	if requests < 4 {
+		t.Errorf("Expected at least %d import requests, received %d", 4, requests)
+	}
+	for id, idVersions := range versions {
+		for i, version := range idVersions {
+			if version != i {
+				t.Errorf("Expected document %s versions to be imported in order, received %v", id, idVersions)
+				break
+			}
+		}
+	}
+}
+
+func TestImportDocumentsParallel_requestError(t *testing.T) {
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"message": "collection not found"}`)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	documents := []interface{}{testDocument, testDocument, testDocument}
+	report, err := client.ImportDocumentsParallel(collectionNameTest, documents, 2, nil)
+	if err != ErrCollectionNotFound {
+		t.Errorf("Expected to receive error %v, received %v", ErrCollectionNotFound, err)
+	}
+	if report.NumFailed != len(documents) {
+		t.Errorf("Expected %d failed documents, received %d", len(documents), report.NumFailed)
+	}
+}
+
+func TestImportDocumentsParallel_retryKeepsOrder(t *testing.T) {
+	type versionedDocument struct {
+		ID      string `json:"id"`
+		Version int    `json:"version"`
+	}
+	var (
+		mu       sync.Mutex
+		attempts int
+		stored   = make(map[string]int)
+	)
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		var lines []string
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		scanner := bufio.NewScanner(req.Body)
+		for scanner.Scan() {
+			var document versionedDocument
+			json.Unmarshal(scanner.Bytes(), &document)
+			if attempts == 1 && document.Version == 1 {
+				lines = append(lines, `{"success": false, "error": "Not Ready or Lagging", "code": 503}`)
+				continue
+			}
+			stored[document.ID] = document.Version
+			lines = append(lines, `{"success": true}`)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(strings.Join(lines, "\n"))),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	documents := []interface{}{
+		versionedDocument{ID: "1", Version: 1},
+		versionedDocument{ID: "1", Version: 2},
+	}
+	report, err := client.ImportDocumentsParallel(collectionNameTest, documents, 1, &ImportOptions{
+		Action:        ImportActionUpsert,
+		MaxRetries:    1,
+		RetryInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if report.NumImported != len(documents) {
+		t.Errorf("Expected to import %d documents, imported %d", len(documents), report.NumImported)
+	}
+	if stored["1"] != 2 {
+		t.Errorf("Expected the stored document to be version %d, received %d", 2, stored["1"])
+	}
+}
+
+func TestImportDocuments_requestErrorReport(t *testing.T) {
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"message": "collection not found"}`)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	documents := []interface{}{testDocument, testDocument, testDocument}
+	imports := map[string]func(opts *ImportOptions) (*ImportReport, error){
+		"sequential": func(opts *ImportOptions) (*ImportReport, error) {
+			return client.ImportDocuments(collectionNameTest, documents, opts)
+		},
+		"parallel": func(opts *ImportOptions) (*ImportReport, error) {
+			return client.ImportDocumentsParallel(collectionNameTest, documents, 2, opts)
+		},
+	}
+	for name, importDocuments := range imports {
+		var deadLetters bytes.Buffer
+		report, err := importDocuments(&ImportOptions{BatchSize: 1, DeadLetter: NewJSONLDeadLetterSink(&deadLetters)})
+		if err != ErrCollectionNotFound {
+			t.Errorf("%s: expected to receive error %v, received %v", name, ErrCollectionNotFound, err)
+		}
+		if len(report.Results) != len(documents) || report.NumFailed != len(documents) {
+			t.Errorf("%s: expected %d failed documents, received %+v", name, len(documents), report)
+		}
+		if letters := strings.Count(deadLetters.String(), "\n"); letters != len(documents) {
+			t.Errorf("%s: expected %d dead letters, received %d", name, len(documents), letters)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package typesense
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// Preset is the representation of a search preset, a named set of
+// search parameters that searches reference with SearchOptions.Preset.
+// The value is either the parameters of a search or, for a multi
+// search, an object with the searches.
+type Preset struct {
+	Name  string                 `json:"name,omitempty"`
+	Value map[string]interface{} `json:"value"`
+}
+
+// NewSearchPreset creates a preset with the parameters of the search
+// options. The query and query by fields are optional, so they can be
+// given by the searches that use the preset.
+func NewSearchPreset(presetName string, searchOptions *SearchOptions) (*Preset, error) {
+	params, err := searchOptions.encodeValues()
+	if err != nil {
+		return nil, err
+	}
+	value := make(map[string]interface{}, len(params))
+	for key := range params {
+		value[key] = params.Get(key)
+	}
+	return &Preset{Name: presetName, Value: value}, nil
+}
+
+// NewMultiSearchPreset creates a preset with the searches of a multi
+// search.
+func NewMultiSearchPreset(presetName string, searches []MultiSearchRequest) (*Preset, error) {
+	params, err := multiSearchParams(searches)
+	if err != nil {
+		return nil, err
+	}
+	return &Preset{Name: presetName, Value: map[string]interface{}{"searches": params}}, nil
+}
+
+// CreatePreset creates a new preset or updates the preset if it already
+// exists.
+func (c *Client) CreatePreset(presetName string, preset *Preset) (*Preset, error) {
+	method := http.MethodPut
+	url := fmt.Sprintf(
+		"%s://%s:%s/presets/%s",
+		c.masterNode.Protocol,
+		c.masterNode.Host,
+		c.masterNode.Port,
+		presetName,
+	)
+	body, err := json.Marshal(preset)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.apiCall(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != 200 {
+		responseBody, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, HTTPError{
+			Status:       res.StatusCode,
+			ResponseBody: responseBody,
+		}
+	}
+	var upsertPreset Preset
+	if err := json.NewDecoder(res.Body).Decode(&upsertPreset); err != nil {
+		return nil, err
+	}
+	return &upsertPreset, nil
+}
+
+// RetrievePreset retrieves a preset by its name.
+func (c *Client) RetrievePreset(presetName string) (*Preset, error) {
+	method := http.MethodGet
+	url := fmt.Sprintf(
+		"%s://%s:%s/presets/%s",
+		c.masterNode.Protocol,
+		c.masterNode.Host,
+		c.masterNode.Port,
+		presetName,
+	)
+	res, err := c.apiCall(method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != 200 {
+		responseBody, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, HTTPError{
+			Status:       res.StatusCode,
+			ResponseBody: responseBody,
+		}
+	}
+	var preset Preset
+	if err := json.NewDecoder(res.Body).Decode(&preset); err != nil {
+		return nil, err
+	}
+	return &preset, nil
+}
+
+// RetrievePresets retrieves all presets in Typesense.
+func (c *Client) RetrievePresets() ([]*Preset, error) {
+	method := http.MethodGet
+	url := fmt.Sprintf(
+		"%s://%s:%s/presets",
+		c.masterNode.Protocol,
+		c.masterNode.Host,
+		c.masterNode.Port,
+	)
+	res, err := c.apiCall(method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != 200 {
+		responseBody, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, HTTPError{
+			Status:       res.StatusCode,
+			ResponseBody: responseBody,
+		}
+	}
+	var body struct {
+		Presets []*Preset `json:"presets"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	return body.Presets, nil
+}
+
+// DeletePreset deletes a preset by its name.
+func (c *Client) DeletePreset(presetName string) (*Preset, error) {
+	method := http.MethodDelete
+	url := fmt.Sprintf(
+		"%s://%s:%s/presets/%s",
+		c.masterNode.Protocol,
+		c.masterNode.Host,
+		c.masterNode.Port,
+		presetName,
+	)
+	res, err := c.apiCall(method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != 200 {
+		responseBody, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, HTTPError{
+			Status:       res.StatusCode,
+			ResponseBody: responseBody,
+		}
+	}
+	var preset Preset
+	if err := json.NewDecoder(res.Body).Decode(&preset); err != nil {
+		return nil, err
+	}
+	return &preset, nil
+}
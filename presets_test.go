@@ -0,0 +1,152 @@
+package typesense
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewSearchPreset(t *testing.T) {
+	perPage := 20
+	preset, err := NewSearchPreset("listing", &SearchOptions{
+		QueryBy: []string{"title", "description"},
+		Filter:  Eq("in_stock", true),
+		Sort:    []SortField{Desc("rating")},
+		PerPage: &perPage,
+	})
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	expected := map[string]interface{}{
+		"query_by":  "title,description",
+		"filter_by": "in_stock:=true",
+		"sort_by":   "rating:desc",
+		"per_page":  "20",
+	}
+	if preset.Name != "listing" || !reflect.DeepEqual(preset.Value, expected) {
+		t.Errorf("Expected preset value %v, received %v", expected, preset.Value)
+	}
+}
+
+func TestNewMultiSearchPreset(t *testing.T) {
+	preset, err := NewMultiSearchPreset("everything", []MultiSearchRequest{
+		{Collection: "books", Options: &SearchOptions{QueryBy: []string{"title"}}},
+		{Collection: "movies"},
+	})
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	data, _ := json.Marshal(preset)
+	expected := `{"name":"everything","value":{"searches":[{"collection":"books","query_by":"title"},{"collection":"movies"}]}}`
+	if string(data) != expected {
+		t.Errorf("Expected preset %s, received %s", expected, data)
+	}
+}
+
+func TestCreatePreset(t *testing.T) {
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodPut || req.URL.Path != "/presets/listing" {
+			t.Errorf("Expected PUT /presets/listing, received %s %s", req.Method, req.URL.Path)
+		}
+		body, _ := ioutil.ReadAll(req.Body)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(string(body))),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	preset := Preset{Name: "listing", Value: map[string]interface{}{"query_by": "title"}}
+	created, err := client.CreatePreset(preset.Name, &preset)
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if !reflect.DeepEqual(*created, preset) {
+		t.Errorf("Expected to receive %v, received %v", preset, *created)
+	}
+}
+
+func TestRetrievePreset(t *testing.T) {
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"name": "listing", "value": {"query_by": "title"}}`)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	preset, err := client.RetrievePreset("listing")
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if preset.Value["query_by"] != "title" {
+		t.Errorf("Expected the preset value to be decoded, received %v", preset.Value)
+	}
+}
+
+func TestRetrievePresets(t *testing.T) {
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: ioutil.NopCloser(strings.NewReader(
+				`{"presets": [{"name": "listing", "value": {}}, {"name": "everything", "value": {"searches": []}}]}`,
+			)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	presets, err := client.RetrievePresets()
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if len(presets) != 2 || presets[1].Name != "everything" {
+		t.Errorf("Expected to receive 2 presets, received %v", presets)
+	}
+}
+
+func TestDeletePreset(t *testing.T) {
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodDelete {
+			t.Errorf("Expected method %s, received %s", http.MethodDelete, req.Method)
+		}
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"message": "Not found."}`)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	if _, err := client.DeletePreset("listing"); err == nil {
+		t.Errorf("Expected to receive an error")
+	}
+}
+
+func TestSearch_preset(t *testing.T) {
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		if preset := req.URL.Query().Get("preset"); preset != "listing" {
+			t.Errorf("Expected preset %q, received %q", "listing", preset)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(searchResultTest)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	if _, err := client.Search("books", "", nil, &SearchOptions{Query: "harry", Preset: "listing"}); err != nil {
+		t.Errorf("Expected to receive no errors, received %v", err)
+	}
+}
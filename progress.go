@@ -0,0 +1,168 @@
+package typesense
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Progress is a snapshot of the progress of a bulk operation.
+type Progress struct {
+	// Processed is the number of documents processed so far.
+	Processed int
+
+	// Failed is the number of processed documents that failed.
+	Failed int
+
+	// Total is the total number of documents, it is 0 when unknown.
+	Total int
+
+	// Bytes is the number of bytes sent to or received from Typesense.
+	Bytes int64
+
+	// Elapsed is the time elapsed since the operation started.
+	Elapsed time.Duration
+
+	// Rate is the number of documents processed per second.
+	Rate float64
+
+	// ETA is the estimated time until the operation is done, it is 0
+	// when the total is unknown.
+	ETA time.Duration
+}
+
+// ProgressObserver receives the progress of bulk operations. OnProgress
+// is called every time a batch of documents is processed, calls are
+// never concurrent for the same operation.
+type ProgressObserver interface {
+	OnProgress(progress Progress)
+}
+
+// ProgressObserverFunc is an adapter to use a function as a
+// ProgressObserver.
+type ProgressObserverFunc func(progress Progress)
+
+// OnProgress calls f(progress).
+func (f ProgressObserverFunc) OnProgress(progress Progress) {
+	f(progress)
+}
+
+// LogProgressObserver is a ProgressObserver that logs the progress at
+// most once per interval and when the operation is done.
+type LogProgressObserver struct {
+	logger   *log.Logger
+	prefix   string
+	interval time.Duration
+
+	mu      sync.Mutex
+	lastLog time.Time
+}
+
+// NewLogProgressObserver creates a LogProgressObserver that logs the
+// progress using logger, or the standard logger when logger is nil,
+// with every message starting with prefix.
+func NewLogProgressObserver(logger *log.Logger, prefix string, interval time.Duration) *LogProgressObserver {
+	return &LogProgressObserver{
+		logger:   logger,
+		prefix:   prefix,
+		interval: interval,
+	}
+}
+
+// OnProgress logs the progress if the interval has elapsed since the
+// last message or if the operation is done.
+func (o *LogProgressObserver) OnProgress(progress Progress) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	now := time.Now()
+	done := progress.Total > 0 && progress.Processed >= progress.Total
+	if !done && now.Sub(o.lastLog) < o.interval {
+		return
+	}
+	o.lastLog = now
+	message := o.prefix + formatProgress(progress)
+	if o.logger != nil {
+		o.logger.Println(message)
+	} else {
+		log.Println(message)
+	}
+}
+
+func formatProgress(progress Progress) string {
+	processed := fmt.Sprintf("%d documents", progress.Processed)
+	if progress.Total > 0 {
+		processed = fmt.Sprintf(
+			"%d/%d documents (%.1f%%)",
+			progress.Processed,
+			progress.Total,
+			100*float64(progress.Processed)/float64(progress.Total),
+		)
+	}
+	message := fmt.Sprintf(
+		"%s, %d failed, %d bytes, %.1f docs/s",
+		processed,
+		progress.Failed,
+		progress.Bytes,
+		progress.Rate,
+	)
+	if progress.Total > 0 {
+		message += fmt.Sprintf(", ETA %s", progress.ETA.Round(time.Second))
+	}
+	return message
+}
+
+// progressTracker accumulates the progress of a bulk operation and
+// notifies the observer. A nil tracker ignores every update.
+type progressTracker struct {
+	observer ProgressObserver
+	start    time.Time
+
+	mu       sync.Mutex
+	progress Progress
+}
+
+func newProgressTracker(observer ProgressObserver, total int) *progressTracker {
+	if observer == nil {
+		return nil
+	}
+	return &progressTracker{
+		observer: observer,
+		start:    time.Now(),
+		progress: Progress{Total: total},
+	}
+}
+
+// addBytes records bytes sent or received without notifying the
+// observer.
+func (t *progressTracker) addBytes(n int64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.progress.Bytes += n
+	t.mu.Unlock()
+}
+
+// addDocuments records processed documents and notifies the observer.
+func (t *progressTracker) addDocuments(processed, failed int) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.progress.Processed += processed
+	t.progress.Failed += failed
+	t.progress.Elapsed = time.Since(t.start)
+	if seconds := t.progress.Elapsed.Seconds(); seconds > 0 {
+		t.progress.Rate = float64(t.progress.Processed) / seconds
+	}
+	if t.progress.Total > 0 && t.progress.Rate > 0 {
+		remaining := t.progress.Total - t.progress.Processed
+		if remaining < 0 {
+			remaining = 0
+		}
+		t.progress.ETA = time.Duration(float64(remaining) / t.progress.Rate * float64(time.Second))
+	}
+	t.observer.OnProgress(t.progress)
+}
@@ -0,0 +1,68 @@
+package typesense
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestImportDocuments_progress(t *testing.T) {
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		var lines []string
+		scanner := bufio.NewScanner(req.Body)
+		for scanner.Scan() {
+			if strings.Contains(scanner.Text(), "invalid") {
+				lines = append(lines, `{"success": false, "error": "bad document"}`)
+			} else {
+				lines = append(lines, `{"success": true}`)
+			}
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(strings.Join(lines, "\n"))),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	var progresses []Progress
+	documents := []interface{}{testDocument, testDocumentStruct{Field1: "invalid"}, testDocument}
+	_, err := client.ImportDocuments(collectionNameTest, documents, &ImportOptions{
+		BatchSize: 2,
+		Progress: ProgressObserverFunc(func(progress Progress) {
+			progresses = append(progresses, progress)
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if len(progresses) != 2 {
+		t.Fatalf("Expected %d progress updates, received %d", 2, len(progresses))
+	}
+	last := progresses[1]
+	if last.Processed != 3 || last.Failed != 1 || last.Total != 3 || last.ETA != 0 {
+		t.Errorf("Expected 3 of 3 processed and 1 failed, received %+v", last)
+	}
+	if last.Bytes <= progresses[0].Bytes {
+		t.Errorf("Expected sent bytes to increase, received %d and %d", progresses[0].Bytes, last.Bytes)
+	}
+}
+
+func TestLogProgressObserver(t *testing.T) {
+	var buf bytes.Buffer
+	observer := NewLogProgressObserver(log.New(&buf, "", 0), "backfill: ", time.Hour)
+	observer.OnProgress(Progress{Processed: 10, Total: 100, Rate: 5, ETA: 18 * time.Second})
+	observer.OnProgress(Progress{Processed: 20, Total: 100})
+	observer.OnProgress(Progress{Processed: 100, Total: 100, Failed: 2, Bytes: 2048, Rate: 50})
+	expected := "backfill: 10/100 documents (10.0%), 0 failed, 0 bytes, 5.0 docs/s, ETA 18s\n" +
+		"backfill: 100/100 documents (100.0%), 2 failed, 2048 bytes, 50.0 docs/s, ETA 0s\n"
+	if buf.String() != expected {
+		t.Errorf("Expected to log %q, logged %q", expected, buf.String())
+	}
+}
@@ -0,0 +1,235 @@
+package typesense
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSearchCacheMaxEntries = 1000
+	defaultSearchCacheTTL        = time.Minute
+)
+
+// SearchCacheConfig is the configuration of the search cache.
+type SearchCacheConfig struct {
+	// MaxEntries is the maximum number of cached responses, the least
+	// recently used response is evicted when it is exceeded. Default
+	// value is 1000.
+	MaxEntries int
+
+	// TTL is the time a response stays cached, unless the search sets
+	// its own SearchCacheTTL. Default value is one minute.
+	TTL time.Duration
+}
+
+// SearchCacheStats are the counters of the search cache.
+type SearchCacheStats struct {
+	// Hits is the number of searches served from the cache.
+	Hits uint64
+
+	// Misses is the number of searches that were not cached, including
+	// the ones that waited for an identical search in flight.
+	Misses uint64
+
+	// Entries is the number of cached responses.
+	Entries int
+}
+
+// EnableSearchCache caches the responses of the searches made by the
+// client, keyed by the collection and the search options. Concurrent
+// identical searches that miss the cache are sent once. The responses
+// of a collection are invalidated when the client writes to it, by
+// indexing, deleting or importing documents, or deletes it. Writes
+// made by other clients or through an alias are not seen. Enabling the
+// cache again replaces it with an empty cache. A search that missed the
+// cache is sent without the context of the callers, so it is not
+// canceled with them, every caller stops waiting for it when its own
+// context is done.
+func (c *Client) EnableSearchCache(config SearchCacheConfig) {
+	if config.MaxEntries <= 0 {
+		config.MaxEntries = defaultSearchCacheMaxEntries
+	}
+	if config.TTL <= 0 {
+		config.TTL = defaultSearchCacheTTL
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.searchCache = newSearchCache(config)
+}
+
+// DisableSearchCache stops caching the searches and drops the cached
+// responses.
+func (c *Client) DisableSearchCache() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.searchCache = nil
+}
+
+// SearchCacheStats returns the counters of the search cache, they are
+// zero when the cache is disabled.
+func (c *Client) SearchCacheStats() SearchCacheStats {
+	cache := c.currentSearchCache()
+	if cache == nil {
+		return SearchCacheStats{}
+	}
+	return cache.stats()
+}
+
+func (c *Client) currentSearchCache() *searchCache {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.searchCache
+}
+
+// invalidateSearchCache drops the cached responses of the collection.
+func (c *Client) invalidateSearchCache(collectionName string) {
+	if cache := c.currentSearchCache(); cache != nil {
+		cache.invalidate(collectionName)
+	}
+}
+
+// searchCache is a LRU cache of search responses with a TTL.
+type searchCache struct {
+	config SearchCacheConfig
+	now    func() time.Time
+
+	mu          sync.Mutex
+	entries     map[string]*list.Element
+	lru         *list.List
+	calls       map[string]*searchCacheCall
+	generations map[string]uint64
+	hits        uint64
+	misses      uint64
+}
+
+type searchCacheEntry struct {
+	key            string
+	collectionName string
+	body           []byte
+	expires        time.Time
+}
+
+// searchCacheCall is a search in flight, waited by the identical
+// searches made meanwhile. done is closed once body and err are set.
+type searchCacheCall struct {
+	done chan struct{}
+	body []byte
+	err  error
+}
+
+// wait waits for the search to be done or for ctx to be done.
+func (call *searchCacheCall) wait(ctx context.Context) ([]byte, error) {
+	select {
+	case <-call.done:
+		return call.body, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func newSearchCache(config SearchCacheConfig) *searchCache {
+	return &searchCache{
+		config:      config,
+		now:         time.Now,
+		entries:     make(map[string]*list.Element),
+		lru:         list.New(),
+		calls:       make(map[string]*searchCacheCall),
+		generations: make(map[string]uint64),
+	}
+}
+
+// get returns the cached response of the search, fetching it when it
+// is not cached. The fetch is shared by the identical searches made
+// meanwhile and runs on a context that is never canceled, ctx only
+// bounds the wait of the caller. The response is cached for ttl, or
+// for the TTL of the cache when ttl is not positive. Errors are not
+// cached.
+func (sc *searchCache) get(ctx context.Context, collectionName, urlEncodedForm string, ttl time.Duration, fetch func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	key := collectionName + "\x00" + urlEncodedForm
+	sc.mu.Lock()
+	if element, ok := sc.entries[key]; ok {
+		entry := element.Value.(*searchCacheEntry)
+		if sc.now().Before(entry.expires) {
+			sc.lru.MoveToFront(element)
+			sc.hits++
+			sc.mu.Unlock()
+			return entry.body, nil
+		}
+		sc.remove(element)
+	}
+	sc.misses++
+	if call, ok := sc.calls[key]; ok {
+		sc.mu.Unlock()
+		return call.wait(ctx)
+	}
+	call := &searchCacheCall{done: make(chan struct{})}
+	sc.calls[key] = call
+	generation := sc.generations[collectionName]
+	sc.mu.Unlock()
+	if ttl <= 0 {
+		ttl = sc.config.TTL
+	}
+
+	go func() {
+		body, err := fetch(context.Background())
+
+		sc.mu.Lock()
+		delete(sc.calls, key)
+		if err == nil && sc.generations[collectionName] == generation {
+			sc.add(&searchCacheEntry{
+				key:            key,
+				collectionName: collectionName,
+				body:           body,
+				expires:        sc.now().Add(ttl),
+			})
+		}
+		sc.mu.Unlock()
+		call.body, call.err = body, err
+		close(call.done)
+	}()
+	return call.wait(ctx)
+}
+
+// add caches the entry, evicting the least recently used entries over
+// the size limit.
+func (sc *searchCache) add(entry *searchCacheEntry) {
+	if element, ok := sc.entries[entry.key]; ok {
+		sc.remove(element)
+	}
+	sc.entries[entry.key] = sc.lru.PushFront(entry)
+	for sc.lru.Len() > sc.config.MaxEntries {
+		sc.remove(sc.lru.Back())
+	}
+}
+
+func (sc *searchCache) remove(element *list.Element) {
+	sc.lru.Remove(element)
+	delete(sc.entries, element.Value.(*searchCacheEntry).key)
+}
+
+// invalidate drops the entries of the collection, responses of the
+// searches in flight are not cached.
+func (sc *searchCache) invalidate(collectionName string) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.generations[collectionName]++
+	for element := sc.lru.Front(); element != nil; {
+		next := element.Next()
+		if element.Value.(*searchCacheEntry).collectionName == collectionName {
+			sc.remove(element)
+		}
+		element = next
+	}
+}
+
+func (sc *searchCache) stats() SearchCacheStats {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return SearchCacheStats{
+		Hits:    sc.hits,
+		Misses:  sc.misses,
+		Entries: sc.lru.Len(),
+	}
+}
@@ -0,0 +1,217 @@
+package typesense
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// searchCacheTest mocks every request and counts the searches sent.
+func searchCacheTest(release <-chan struct{}) *int32 {
+	var searches int32
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodGet {
+			atomic.AddInt32(&searches, 1)
+			if release != nil {
+				<-release
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(searchResultTest)),
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"id": "1"}`)),
+		}, nil
+	}
+	return &searches
+}
+
+func TestSearchCache(t *testing.T) {
+	searches := searchCacheTest(nil)
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	client.EnableSearchCache(SearchCacheConfig{})
+	for i := 0; i < 3; i++ {
+		searchResp, err := client.Search("books", "harry potter", []string{"title"}, nil)
+		if err != nil {
+			t.Fatalf("Expected to receive no errors, received %v", err)
+		}
+		if searchResp.Found != 62 {
+			t.Errorf("Expected %d documents found, received %d", 62, searchResp.Found)
+		}
+	}
+	if _, err := client.Search("books", "lord of the rings", []string{"title"}, nil); err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if *searches != 2 {
+		t.Errorf("Expected %d searches to be sent, received %d", 2, *searches)
+	}
+	expected := SearchCacheStats{Hits: 2, Misses: 2, Entries: 2}
+	if stats := client.SearchCacheStats(); stats != expected {
+		t.Errorf("Expected stats %+v, received %+v", expected, stats)
+	}
+}
+
+func TestSearchCache_invalidation(t *testing.T) {
+	searches := searchCacheTest(nil)
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	client.EnableSearchCache(SearchCacheConfig{})
+	client.Search("books", "harry potter", []string{"title"}, nil)
+	client.Search("movies", "harry potter", []string{"title"}, nil)
+	if resp := client.IndexDocument("books", testDocument); resp.Error != nil {
+		t.Fatalf("Expected to receive no errors, received %v", resp.Error)
+	}
+	client.Search("books", "harry potter", []string{"title"}, nil)
+	client.Search("movies", "harry potter", []string{"title"}, nil)
+	if *searches != 3 {
+		t.Errorf("Expected %d searches to be sent, received %d", 3, *searches)
+	}
+	client.DeleteDocument("movies", "1")
+	client.Search("movies", "harry potter", []string{"title"}, nil)
+	if *searches != 4 {
+		t.Errorf("Expected %d searches to be sent, received %d", 4, *searches)
+	}
+}
+
+func TestSearchCache_evictionAndTTL(t *testing.T) {
+	searches := searchCacheTest(nil)
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	client.EnableSearchCache(SearchCacheConfig{MaxEntries: 2, TTL: time.Minute})
+	now := time.Now()
+	client.searchCache.now = func() time.Time { return now }
+	client.Search("books", "a", []string{"title"}, nil)
+	client.Search("books", "b", []string{"title"}, nil)
+	client.Search("books", "a", []string{"title"}, nil)
+	client.Search("books", "c", []string{"title"}, nil)
+	if *searches != 3 {
+		t.Fatalf("Expected %d searches to be sent, received %d", 3, *searches)
+	}
+	client.Search("books", "a", []string{"title"}, nil)
+	if *searches != 3 {
+		t.Errorf("Expected the recently used search to stay cached")
+	}
+	client.Search("books", "b", []string{"title"}, nil)
+	if *searches != 4 {
+		t.Errorf("Expected the least recently used search to be evicted")
+	}
+	now = now.Add(time.Minute)
+	client.Search("books", "b", []string{"title"}, nil)
+	if *searches != 5 {
+		t.Errorf("Expected the expired search to be sent again")
+	}
+}
+
+func TestSearchCache_searchTTL(t *testing.T) {
+	searches := searchCacheTest(nil)
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	client.EnableSearchCache(SearchCacheConfig{TTL: time.Minute})
+	now := time.Now()
+	client.searchCache.now = func() time.Time { return now }
+	short := &SearchOptions{Query: "a", QueryBy: []string{"title"}, SearchCacheTTL: time.Second}
+	long := &SearchOptions{Query: "b", QueryBy: []string{"title"}, SearchCacheTTL: time.Hour}
+	client.Search("books", "", nil, short)
+	client.Search("books", "", nil, long)
+	client.Search("books", "c", []string{"title"}, nil)
+	now = now.Add(time.Second)
+	client.Search("books", "", nil, short)
+	client.Search("books", "c", []string{"title"}, nil)
+	if *searches != 4 {
+		t.Fatalf("Expected only the search with the short TTL to expire, received %d searches", *searches)
+	}
+	now = now.Add(time.Minute)
+	client.Search("books", "", nil, long)
+	client.Search("books", "c", []string{"title"}, nil)
+	if *searches != 5 {
+		t.Errorf("Expected only the search with the cache TTL to expire, received %d searches", *searches)
+	}
+}
+
+func TestSearchCache_concurrentMisses(t *testing.T) {
+	release := make(chan struct{})
+	searches := searchCacheTest(release)
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	client.EnableSearchCache(SearchCacheConfig{})
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Search("books", "harry potter", []string{"title"}, nil); err != nil {
+				t.Errorf("Expected to receive no errors, received %v", err)
+			}
+		}()
+	}
+	for client.SearchCacheStats().Misses < 5 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+	if *searches != 1 {
+		t.Errorf("Expected %d search to be sent, received %d", 1, *searches)
+	}
+}
+
+func TestSearchCache_canceledCaller(t *testing.T) {
+	release := make(chan struct{})
+	searches := searchCacheTest(release)
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	client.EnableSearchCache(SearchCacheConfig{})
+	searchOptions := &SearchOptions{Query: "harry potter", QueryBy: []string{"title"}}
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan error)
+	go func() {
+		var searchResp SearchResponse
+		canceled <- client.searchContext(ctx, "books", searchOptions, &searchResp)
+	}()
+	waited := make(chan error)
+	go func() {
+		for client.SearchCacheStats().Misses < 1 {
+			time.Sleep(time.Millisecond)
+		}
+		var searchResp SearchResponse
+		waited <- client.searchContext(context.Background(), "books", searchOptions, &searchResp)
+	}()
+	for client.SearchCacheStats().Misses < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	select {
+	case err := <-canceled:
+		if err != context.Canceled {
+			t.Errorf("Expected to receive error %v, received %v", context.Canceled, err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Expected the canceled search to stop waiting")
+	}
+	close(release)
+	if err := <-waited; err != nil {
+		t.Errorf("Expected the waiting search to receive no errors, received %v", err)
+	}
+	if *searches != 1 {
+		t.Errorf("Expected %d search to be sent, received %d", 1, *searches)
+	}
+}
@@ -0,0 +1,91 @@
+package typesense
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// UnmarshalJSON decodes the hit, keeping the raw JSON of its document
+// in RawDocument so it can be decoded into a struct without encoding
+// the Document map again.
+func (h *SearchResultHit) UnmarshalJSON(data []byte) error {
+	type searchResultHit SearchResultHit
+	var hit struct {
+		searchResultHit
+		Document json.RawMessage `json:"document"`
+	}
+	if err := json.Unmarshal(data, &hit); err != nil {
+		return err
+	}
+	*h = SearchResultHit(hit.searchResultHit)
+	h.RawDocument = hit.Document
+	if len(hit.Document) > 0 {
+		if err := json.Unmarshal(hit.Document, &h.Document); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Decode decodes the document of the hit into the value pointed to by
+// document, using the document encoder of the client that made the
+// search, if any.
+func (h *SearchResultHit) Decode(document interface{}) error {
+	return decodeDocument(h.encoder, h.RawDocument, document)
+}
+
+// decodeDocument decodes the JSON encoded document into the value
+// pointed to by document, using the encoder if it is not nil.
+func decodeDocument(encoder *DocumentEncoder, data []byte, document interface{}) error {
+	if encoder != nil {
+		return encoder.Decode(data, document)
+	}
+	return json.Unmarshal(data, document)
+}
+
+// DecodeHits decodes the documents of every hit into dst, which must be
+// a pointer to a slice of structs or of pointers to structs. The slice
+// is replaced by a slice with one element per hit.
+func (r *SearchResponse) DecodeHits(dst interface{}) error {
+	return decodeHits(r.Hits, dst)
+}
+
+// DecodeHits decodes the documents of every hit of the group into dst,
+// as SearchResponse.DecodeHits does.
+func (g *GroupedHit) DecodeHits(dst interface{}) error {
+	return decodeHits(g.Hits, dst)
+}
+
+func decodeHits(hits []SearchResultHit, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("typesense: decode of hits into non-pointer to slice %T", dst)
+	}
+	slice := reflect.MakeSlice(v.Elem().Type(), len(hits), len(hits))
+	for i := range hits {
+		element := slice.Index(i)
+		if element.Kind() == reflect.Ptr {
+			element.Set(reflect.New(element.Type().Elem()))
+		} else {
+			element = element.Addr()
+		}
+		if err := hits[i].Decode(element.Interface()); err != nil {
+			return err
+		}
+	}
+	v.Elem().Set(slice)
+	return nil
+}
+
+// setEncoder sets the document encoder used to decode the hits.
+func (r *SearchResponse) setEncoder(encoder *DocumentEncoder) {
+	for i := range r.Hits {
+		r.Hits[i].encoder = encoder
+	}
+	for i := range r.GroupedHits {
+		for j := range r.GroupedHits[i].Hits {
+			r.GroupedHits[i].Hits[j].encoder = encoder
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package typesense
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const groupedSearchResultTest = `
+	{
+		"facet_counts": [],
+		"found": 2,
+		"found_docs": 3,
+		"hits": [],
+		"grouped_hits": [
+			{
+				"group_key": ["J.K. Rowling"],
+				"found": 2,
+				"hits": [
+					{"highlights": [], "document": {"id": "1", "title": "Harry Potter and the Chamber of Secrets"}},
+					{"highlights": [], "document": {"id": "2", "title": "Harry Potter and the Philosopher's Stone"}}
+				]
+			},
+			{
+				"group_key": ["Suzanne Collins"],
+				"found": 1,
+				"hits": [
+					{"highlights": [], "document": {"id": "3", "title": "The Hunger Games"}}
+				]
+			}
+		]
+	}
+`
+
+type searchHitBook struct {
+	ID              string   `json:"id"`
+	Title           string   `json:"title"`
+	Authors         []string `json:"authors"`
+	PublicationYear int      `json:"publication_year"`
+}
+
+func TestSearchResultHit_UnmarshalJSON(t *testing.T) {
+	var searchResp SearchResponse
+	if err := json.Unmarshal([]byte(searchResultTest), &searchResp); err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	hit := searchResp.Hits[0]
+	if hit.Document["id"] != "2" {
+		t.Errorf("Expected the document map to be decoded, received %v", hit.Document)
+	}
+	if len(hit.Highlights) != 1 {
+		t.Errorf("Expected %d highlight, received %d", 1, len(hit.Highlights))
+	}
+	var document map[string]interface{}
+	if err := json.Unmarshal(hit.RawDocument, &document); err != nil || document["title"] != hit.Document["title"] {
+		t.Errorf("Expected the raw document to be kept, received %s", hit.RawDocument)
+	}
+}
+
+func TestSearchResultHit_Decode(t *testing.T) {
+	var searchResp SearchResponse
+	if err := json.Unmarshal([]byte(searchResultTest), &searchResp); err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	var book searchHitBook
+	if err := searchResp.Hits[0].Decode(&book); err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if book.ID != "2" || book.PublicationYear != 1997 || len(book.Authors) != 2 {
+		t.Errorf("Expected the hit to be decoded, received %+v", book)
+	}
+}
+
+func TestSearchResponse_DecodeHits(t *testing.T) {
+	var searchResp SearchResponse
+	if err := json.Unmarshal([]byte(searchResultTest), &searchResp); err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	var books []searchHitBook
+	if err := searchResp.DecodeHits(&books); err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if len(books) != 1 || books[0].Title != "Harry Potter and the Philosopher's Stone" {
+		t.Errorf("Expected the hits to be decoded, received %+v", books)
+	}
+	var bookPtrs []*searchHitBook
+	if err := searchResp.DecodeHits(&bookPtrs); err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if len(bookPtrs) != 1 || bookPtrs[0].ID != "2" {
+		t.Errorf("Expected the hits to be decoded, received %+v", bookPtrs)
+	}
+	if err := searchResp.DecodeHits(books); err == nil {
+		t.Errorf("Expected to receive an error decoding into a non-pointer")
+	}
+}
+
+func TestSearchResponse_groupedHits(t *testing.T) {
+	var searchResp SearchResponse
+	if err := json.Unmarshal([]byte(groupedSearchResultTest), &searchResp); err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if searchResp.Found != 2 || searchResp.FoundDocs != 3 {
+		t.Errorf("Expected %d groups and %d documents, received %d and %d", 2, 3, searchResp.Found, searchResp.FoundDocs)
+	}
+	if len(searchResp.GroupedHits) != 2 {
+		t.Fatalf("Expected %d groups, received %d", 2, len(searchResp.GroupedHits))
+	}
+	group := searchResp.GroupedHits[0]
+	if len(group.GroupKey) != 1 || group.GroupKey[0] != "J.K. Rowling" || group.Found != 2 {
+		t.Errorf("Expected the group of J.K. Rowling, received %+v", group)
+	}
+	var books []searchHitBook
+	if err := group.DecodeHits(&books); err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if len(books) != 2 || books[1].ID != "2" {
+		t.Errorf("Expected the hits of the group to be decoded, received %+v", books)
+	}
+}
+
+func TestSearchResponse_metadata(t *testing.T) {
+	const response = `
+		{
+			"facet_counts": [
+				{
+					"field_name": "publication_year",
+					"counts": [{"count": 2, "value": "1997", "highlighted": "<mark>199</mark>7"}],
+					"stats": {"min": 1997, "max": 2007, "avg": 2002, "sum": 4004, "total_values": 2}
+				}
+			],
+			"found": 1,
+			"out_of": 100,
+			"page": 2,
+			"search_time_ms": 3,
+			"search_cutoff": true,
+			"request_params": {"collection_name": "books", "per_page": 10, "q": "harry"},
+			"hits": [
+				{
+					"document": {"id": "2"},
+					"highlights": [],
+					"highlight": {"title": {"snippet": "<mark>Harry</mark> Potter", "matched_tokens": ["Harry"]}},
+					"text_match": 578730123365187705,
+					"text_match_info": {
+						"best_field_score": "1108091339008",
+						"best_field_weight": 15,
+						"fields_matched": 1,
+						"num_tokens_dropped": 0,
+						"score": "578730123365187705",
+						"tokens_matched": 1,
+						"typo_prefix_score": 0
+					},
+					"geo_distance_meters": {"location": 1020.5},
+					"vector_distance": 0.25
+				}
+			]
+		}
+	`
+	var searchResp SearchResponse
+	if err := json.Unmarshal([]byte(response), &searchResp); err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if searchResp.OutOf != 100 || searchResp.Page != 2 || searchResp.SearchTimeMs != 3 || !searchResp.SearchCutoff {
+		t.Errorf("Expected the response metadata to be decoded, received %+v", searchResp)
+	}
+	if searchResp.RequestParams == nil || searchResp.RequestParams.CollectionName != "books" || searchResp.RequestParams.Q != "harry" {
+		t.Errorf("Expected the request params to be decoded, received %+v", searchResp.RequestParams)
+	}
+	facetCount := searchResp.FacetCounts[0]
+	if facetCount.Counts[0].Highlighted != "<mark>199</mark>7" {
+		t.Errorf("Expected the highlighted facet value, received %q", facetCount.Counts[0].Highlighted)
+	}
+	if facetCount.Stats == nil || *facetCount.Stats.Min != 1997 || *facetCount.Stats.Max != 2007 ||
+		*facetCount.Stats.Avg != 2002 || *facetCount.Stats.Sum != 4004 || facetCount.Stats.TotalValues != 2 {
+		t.Errorf("Expected the facet stats to be decoded, received %+v", facetCount.Stats)
+	}
+	hit := searchResp.Hits[0]
+	if hit.TextMatch != 578730123365187705 {
+		t.Errorf("Expected text match %d, received %d", int64(578730123365187705), hit.TextMatch)
+	}
+	if hit.TextMatchInfo == nil || hit.TextMatchInfo.BestFieldWeight != 15 || hit.TextMatchInfo.Score != "578730123365187705" {
+		t.Errorf("Expected the text match info to be decoded, received %+v", hit.TextMatchInfo)
+	}
+	if hit.GeoDistanceMeters["location"] != 1020.5 {
+		t.Errorf("Expected the geo distance to be decoded, received %v", hit.GeoDistanceMeters)
+	}
+	if hit.VectorDistance == nil || *hit.VectorDistance != 0.25 {
+		t.Errorf("Expected the vector distance to be decoded, received %v", hit.VectorDistance)
+	}
+	title, _ := hit.Highlight["title"].(map[string]interface{})
+	if title["snippet"] != "<mark>Harry</mark> Potter" {
+		t.Errorf("Expected the nested highlight to be decoded, received %v", hit.Highlight)
+	}
+}
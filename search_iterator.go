@@ -0,0 +1,203 @@
+package typesense
+
+import "context"
+
+// SearchIteratorConfig is the configuration of a SearchIterator.
+type SearchIteratorConfig struct {
+	// Limit is the maximum number of hits yielded by the iterator.
+	// Default value is 0, which yields every hit found.
+	Limit int
+
+	// Prefetch fetches the next page in the background while the
+	// hits of the current page are consumed.
+	Prefetch bool
+}
+
+// SearchIterator walks every hit of a search, fetching the pages
+// lazily as the hits are consumed. It stops when every hit found was
+// yielded, when the limit is reached or when its context is done.
+//
+//	it := client.NewSearchIterator(ctx, "books", opts, typesense.SearchIteratorConfig{})
+//	for it.Next() {
+//		hit := it.Hit()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type SearchIterator struct {
+	client         *Client
+	ctx            context.Context
+	collectionName string
+	searchOptions  SearchOptions
+	config         SearchIteratorConfig
+
+	page     int
+	hits     []SearchResultHit
+	index    int
+	count    int
+	received int
+	found    int
+	started  bool
+	done     bool
+	err      error
+	next     chan searchPage
+}
+
+// searchPage is the result of fetching a page of a search.
+type searchPage struct {
+	response *SearchResponse
+	err      error
+}
+
+// NewSearchIterator creates a SearchIterator over the hits of the
+// search. The page of the search options is the first page fetched,
+// pages are fetched with the per page of the search options.
+func (c *Client) NewSearchIterator(ctx context.Context, collectionName string, searchOptions *SearchOptions, config SearchIteratorConfig) *SearchIterator {
+	it := SearchIterator{
+		client:         c,
+		ctx:            ctx,
+		collectionName: collectionName,
+		config:         config,
+		page:           1,
+		index:          -1,
+	}
+	if searchOptions != nil {
+		it.searchOptions = *searchOptions
+		if searchOptions.Page != nil {
+			it.page = *searchOptions.Page
+		}
+	}
+	return &it
+}
+
+// Next advances the iterator to the next hit, fetching the next page
+// when the hits of the current page were consumed. It returns false
+// when there are no more hits or an error happened, which is returned
+// by Err.
+func (it *SearchIterator) Next() bool {
+	if it.done || it.err != nil {
+		return false
+	}
+	if it.config.Limit > 0 && it.count >= it.config.Limit {
+		it.stop()
+		return false
+	}
+	if it.index+1 >= len(it.hits) {
+		if it.started && !it.hasMore() {
+			it.stop()
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			it.stop()
+			return false
+		}
+		response, err := it.nextPage()
+		if err != nil {
+			it.err = err
+			it.stop()
+			return false
+		}
+		it.started = true
+		it.hits = response.Hits
+		it.index = -1
+		it.found = response.Found
+		it.received += len(response.Hits)
+		it.page++
+		if len(it.hits) == 0 {
+			it.stop()
+			return false
+		}
+		if it.config.Prefetch && it.hasMore() {
+			it.prefetch()
+		}
+	}
+	it.index++
+	it.count++
+	return true
+}
+
+// Hit returns the current hit, it must only be called after a call to
+// Next returned true.
+func (it *SearchIterator) Hit() *SearchResultHit {
+	if it.index < 0 || it.index >= len(it.hits) {
+		return nil
+	}
+	return &it.hits[it.index]
+}
+
+// Found returns the number of documents found by the search, it is
+// known once the first page was fetched.
+func (it *SearchIterator) Found() int {
+	return it.found
+}
+
+// Err returns the error that stopped the iterator, if any.
+func (it *SearchIterator) Err() error {
+	return it.err
+}
+
+// hasMore reports whether there are more hits to fetch after the
+// pages received.
+func (it *SearchIterator) hasMore() bool {
+	if len(it.hits) == 0 {
+		return false
+	}
+	if it.config.Limit > 0 && it.count+len(it.hits)-it.index-1 >= it.config.Limit {
+		return false
+	}
+	first := 1
+	if it.searchOptions.Page != nil {
+		first = *it.searchOptions.Page
+	}
+	perPage := len(it.hits)
+	if it.searchOptions.PerPage != nil {
+		perPage = *it.searchOptions.PerPage
+	}
+	return (first-1)*perPage+it.received < it.found
+}
+
+// nextPage returns the next page, either the prefetched one or a new
+// fetch of it.
+func (it *SearchIterator) nextPage() (*SearchResponse, error) {
+	if it.next == nil {
+		return it.fetch(it.page)
+	}
+	next := it.next
+	it.next = nil
+	select {
+	case page := <-next:
+		return page.response, page.err
+	case <-it.ctx.Done():
+		return nil, it.ctx.Err()
+	}
+}
+
+// prefetch fetches the next page in the background.
+func (it *SearchIterator) prefetch() {
+	next := make(chan searchPage, 1)
+	page := it.page
+	go func() {
+		response, err := it.fetch(page)
+		next <- searchPage{response: response, err: err}
+	}()
+	it.next = next
+}
+
+func (it *SearchIterator) fetch(page int) (*SearchResponse, error) {
+	searchOptions := it.searchOptions
+	searchOptions.Page = &page
+	var searchResponse SearchResponse
+	if err := it.client.searchContext(it.ctx, it.collectionName, &searchOptions, &searchResponse); err != nil {
+		return nil, err
+	}
+	searchResponse.setEncoder(it.client.encoder)
+	return &searchResponse, nil
+}
+
+// stop stops the iterator, a prefetched page is discarded.
+func (it *SearchIterator) stop() {
+	it.done = true
+	it.next = nil
+}
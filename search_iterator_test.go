@@ -0,0 +1,152 @@
+package typesense
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// searchPagesTest mocks a search that found documents in pages of
+// perPage hits, returning the pages requested.
+func searchPagesTest(t *testing.T, found, perPage int) *[]int {
+	var mu sync.Mutex
+	var pages []int
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		page, err := strconv.Atoi(req.URL.Query().Get("page"))
+		if err != nil {
+			t.Errorf("Expected the page to be requested, received %q", req.URL.Query().Get("page"))
+		}
+		mu.Lock()
+		pages = append(pages, page)
+		mu.Unlock()
+		var hits []string
+		for i := (page - 1) * perPage; i < page*perPage && i < found; i++ {
+			hits = append(hits, fmt.Sprintf(`{"highlights": [], "document": {"id": "%d"}}`, i))
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: ioutil.NopCloser(strings.NewReader(
+				fmt.Sprintf(`{"facet_counts": [], "found": %d, "hits": [%s]}`, found, strings.Join(hits, ",")),
+			)),
+		}, nil
+	}
+	return &pages
+}
+
+func TestSearchIterator(t *testing.T) {
+	for _, prefetch := range []bool{false, true} {
+		pages := searchPagesTest(t, 25, 10)
+		client := Client{
+			httpClient: mockClient,
+			masterNode: testMasterNode,
+		}
+		perPage := 10
+		it := client.NewSearchIterator(
+			context.Background(),
+			"books",
+			&SearchOptions{Query: "*", QueryBy: []string{"title"}, PerPage: &perPage},
+			SearchIteratorConfig{Prefetch: prefetch},
+		)
+		var ids []string
+		for it.Next() {
+			ids = append(ids, it.Hit().Document["id"].(string))
+		}
+		if err := it.Err(); err != nil {
+			t.Fatalf("Expected to receive no errors, received %v", err)
+		}
+		if len(ids) != 25 || ids[0] != "0" || ids[24] != "24" {
+			t.Errorf("Expected every hit to be yielded in order, received %v", ids)
+		}
+		if len(*pages) != 3 {
+			t.Errorf("Expected %d pages to be fetched, received %v", 3, *pages)
+		}
+		if it.Found() != 25 {
+			t.Errorf("Expected %d documents found, received %d", 25, it.Found())
+		}
+	}
+}
+
+func TestSearchIterator_limit(t *testing.T) {
+	pages := searchPagesTest(t, 25, 10)
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	perPage := 10
+	it := client.NewSearchIterator(
+		context.Background(),
+		"books",
+		&SearchOptions{Query: "*", QueryBy: []string{"title"}, PerPage: &perPage},
+		SearchIteratorConfig{Limit: 15, Prefetch: true},
+	)
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if count != 15 {
+		t.Errorf("Expected %d hits, received %d", 15, count)
+	}
+	if len(*pages) != 2 {
+		t.Errorf("Expected %d pages to be fetched, received %v", 2, *pages)
+	}
+}
+
+func TestSearchIterator_canceled(t *testing.T) {
+	searchPagesTest(t, 25, 10)
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	perPage := 10
+	it := client.NewSearchIterator(
+		ctx,
+		"books",
+		&SearchOptions{Query: "*", QueryBy: []string{"title"}, PerPage: &perPage},
+		SearchIteratorConfig{},
+	)
+	count := 0
+	for it.Next() {
+		count++
+		if count == 10 {
+			cancel()
+		}
+	}
+	if count != 10 {
+		t.Errorf("Expected %d hits, received %d", 10, count)
+	}
+	if it.Err() != context.Canceled {
+		t.Errorf("Expected to receive error %v, received %v", context.Canceled, it.Err())
+	}
+}
+
+func TestSearchIterator_error(t *testing.T) {
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"message": "Not Found"}`)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	it := client.NewSearchIterator(
+		context.Background(),
+		"books",
+		&SearchOptions{Query: "*", QueryBy: []string{"title"}},
+		SearchIteratorConfig{},
+	)
+	if it.Next() {
+		t.Errorf("Expected no hits")
+	}
+	if it.Err() != ErrNotFound {
+		t.Errorf("Expected to receive error %v, received %v", ErrNotFound, it.Err())
+	}
+}
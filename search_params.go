@@ -0,0 +1,315 @@
+package typesense
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SearchParamsError is the error returned by ParseSearchOptions when
+// there are unknown or invalid parameters.
+type SearchParamsError struct {
+	// Unknown are the names of the unknown parameters, sorted.
+	Unknown []string
+
+	// Invalid are the violations of the invalid parameters, their path
+	// is the name of the parameter.
+	Invalid ValidationErrors
+}
+
+// Error returns a string representation of the unknown and invalid
+// parameters.
+func (e *SearchParamsError) Error() string {
+	var messages []string
+	if len(e.Unknown) > 0 {
+		messages = append(messages, "unknown search parameters: "+strings.Join(e.Unknown, ", "))
+	}
+	if len(e.Invalid) > 0 {
+		messages = append(messages, "invalid search parameters: "+e.Invalid.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// ParseSearchOptions parses search parameters in the Typesense query
+// string format, the inverse of the encoding of the search options. The
+// filter_by and sort_by parameters are parsed into FilterBy and SortBy,
+// prefix and num_typos lists into PrefixByField and NumTyposByField.
+// Every parameter must be given once and its value must be of the type
+// of the option, booleans must be true or false. When there are unknown
+// or invalid parameters a *SearchParamsError is returned along with the
+// options parsed from the valid parameters.
+func ParseSearchOptions(params url.Values) (*SearchOptions, error) {
+	var opts SearchOptions
+	var paramsErr SearchParamsError
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		parse, ok := searchParamParsers[name]
+		if !ok {
+			paramsErr.Unknown = append(paramsErr.Unknown, name)
+			continue
+		}
+		values := params[name]
+		var message string
+		switch {
+		case len(values) != 1:
+			message = "parameter must be given once"
+		case values[0] == "":
+			message = "value must not be empty"
+		default:
+			if err := parse(&opts, values[0]); err != nil {
+				message = err.Error()
+			}
+		}
+		if message != "" {
+			paramsErr.Invalid = append(paramsErr.Invalid, ValidationError{Path: name, Message: message})
+		}
+	}
+	if len(opts.SortBy) > maxSortFields {
+		paramsErr.Invalid = append(paramsErr.Invalid, ValidationError{
+			Path:    "sort_by",
+			Message: fmt.Sprintf("at most %d sort fields can be used", maxSortFields),
+		})
+		opts.SortBy = nil
+	}
+	if len(paramsErr.Unknown) > 0 || len(paramsErr.Invalid) > 0 {
+		return &opts, &paramsErr
+	}
+	return &opts, nil
+}
+
+// searchParamParsers parse the value of every search parameter into
+// its option.
+var searchParamParsers = map[string]func(opts *SearchOptions, value string) error{
+	"q":                         stringParam(func(opts *SearchOptions) *string { return &opts.Query }),
+	"query_by":                  listParam(func(opts *SearchOptions) *[]string { return &opts.QueryBy }),
+	"max_hits":                  intParam(func(opts *SearchOptions) **int { return &opts.MaxHits }),
+	"prefix":                    prefixParam,
+	"filter_by":                 filterByParam,
+	"sort_by":                   listParam(func(opts *SearchOptions) *[]string { return &opts.SortBy }),
+	"facet_by":                  listParam(func(opts *SearchOptions) *[]string { return &opts.FacetBy }),
+	"max_facet_values":          intParam(func(opts *SearchOptions) **int { return &opts.MaxFacetValues }),
+	"facet_query":               stringPtrParam(func(opts *SearchOptions) **string { return &opts.FacetQuery }),
+	"num_typos":                 numTyposParam,
+	"page":                      intParam(func(opts *SearchOptions) **int { return &opts.Page }),
+	"per_page":                  intParam(func(opts *SearchOptions) **int { return &opts.PerPage }),
+	"group_by":                  listParam(func(opts *SearchOptions) *[]string { return &opts.GroupBy }),
+	"group_limit":               intParam(func(opts *SearchOptions) **int { return &opts.GroupLimit }),
+	"include_fields":            listParam(func(opts *SearchOptions) *[]string { return &opts.IncludeFields }),
+	"exclude_fields":            listParam(func(opts *SearchOptions) *[]string { return &opts.ExcludeFields }),
+	"highlight_full_fields":     listParam(func(opts *SearchOptions) *[]string { return &opts.HighlightFullFields }),
+	"highlight_start_tag":       stringPtrParam(func(opts *SearchOptions) **string { return &opts.HighlightStartTag }),
+	"highlight_end_tag":         stringPtrParam(func(opts *SearchOptions) **string { return &opts.HighlightEndTag }),
+	"snippet_threshold":         intParam(func(opts *SearchOptions) **int { return &opts.SnippetThreshold }),
+	"drop_tokens_threshold":     intParam(func(opts *SearchOptions) **int { return &opts.DropTokensThreshold }),
+	"typo_tokens_threshold":     intParam(func(opts *SearchOptions) **int { return &opts.TypoTokensThreshold }),
+	"pinned_hits":               listParam(func(opts *SearchOptions) *[]string { return &opts.PinnedHits }),
+	"hidden_hits":               listParam(func(opts *SearchOptions) *[]string { return &opts.Hiddenhits }),
+	"query_by_weights":          intListParam(func(opts *SearchOptions) *[]int { return &opts.QueryByWeights }),
+	"infix":                     listParam(func(opts *SearchOptions) *[]string { return &opts.Infix }),
+	"prioritize_exact_match":    boolParam(func(opts *SearchOptions) **bool { return &opts.PrioritizeExactMatch }),
+	"prioritize_token_position": boolParam(func(opts *SearchOptions) **bool { return &opts.PrioritizeTokenPosition }),
+	"exhaustive_search":         boolParam(func(opts *SearchOptions) **bool { return &opts.ExhaustiveSearch }),
+	"search_cutoff_ms":          intParam(func(opts *SearchOptions) **int { return &opts.SearchCutoffMs }),
+	"enable_overrides":          boolParam(func(opts *SearchOptions) **bool { return &opts.EnableOverrides }),
+	"use_cache":                 boolParam(func(opts *SearchOptions) **bool { return &opts.UseCache }),
+	"cache_ttl":                 intParam(func(opts *SearchOptions) **int { return &opts.CacheTTL }),
+	"highlight_fields":          listParam(func(opts *SearchOptions) *[]string { return &opts.HighlightFields }),
+	"min_len_1typo":             intParam(func(opts *SearchOptions) **int { return &opts.MinLen1Typo }),
+	"min_len_2typo":             intParam(func(opts *SearchOptions) **int { return &opts.MinLen2Typo }),
+	"split_join_tokens":         stringPtrParam(func(opts *SearchOptions) **string { return &opts.SplitJoinTokens }),
+	"text_match_type":           stringPtrParam(func(opts *SearchOptions) **string { return &opts.TextMatchType }),
+	"facet_sample_percent":      intParam(func(opts *SearchOptions) **int { return &opts.FacetSamplePercent }),
+	"max_candidates":            intParam(func(opts *SearchOptions) **int { return &opts.MaxCandidates }),
+	"preset":                    stringParam(func(opts *SearchOptions) *string { return &opts.Preset }),
+	"vector_query":              vectorQueryParam,
+}
+
+func stringParam(option func(opts *SearchOptions) *string) func(*SearchOptions, string) error {
+	return func(opts *SearchOptions, value string) error {
+		*option(opts) = value
+		return nil
+	}
+}
+
+func stringPtrParam(option func(opts *SearchOptions) **string) func(*SearchOptions, string) error {
+	return func(opts *SearchOptions, value string) error {
+		*option(opts) = &value
+		return nil
+	}
+}
+
+func intParam(option func(opts *SearchOptions) **int) func(*SearchOptions, string) error {
+	return func(opts *SearchOptions, value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("value must be an integer")
+		}
+		*option(opts) = &n
+		return nil
+	}
+}
+
+func boolParam(option func(opts *SearchOptions) **bool) func(*SearchOptions, string) error {
+	return func(opts *SearchOptions, value string) error {
+		var b bool
+		switch value {
+		case "true":
+			b = true
+		case "false":
+		default:
+			return fmt.Errorf("value must be true or false")
+		}
+		*option(opts) = &b
+		return nil
+	}
+}
+
+func listParam(option func(opts *SearchOptions) *[]string) func(*SearchOptions, string) error {
+	return func(opts *SearchOptions, value string) error {
+		values := splitParamList(value)
+		for _, v := range values {
+			if v == "" {
+				return fmt.Errorf("list must not have empty values")
+			}
+		}
+		*option(opts) = values
+		return nil
+	}
+}
+
+func intListParam(option func(opts *SearchOptions) *[]int) func(*SearchOptions, string) error {
+	return func(opts *SearchOptions, value string) error {
+		var values []int
+		for _, v := range splitParamList(value) {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("value must be a list of integers")
+			}
+			values = append(values, n)
+		}
+		*option(opts) = values
+		return nil
+	}
+}
+
+// prefixParam parses a single prefix into Prefix and a list of them
+// into PrefixByField.
+func prefixParam(opts *SearchOptions, value string) error {
+	if !strings.Contains(value, ",") {
+		return boolParam(func(opts *SearchOptions) **bool { return &opts.Prefix })(opts, value)
+	}
+	var prefixes []bool
+	for _, v := range splitParamList(value) {
+		if v != "true" && v != "false" {
+			return fmt.Errorf("value must be a list of true or false")
+		}
+		prefixes = append(prefixes, v == "true")
+	}
+	opts.PrefixByField = prefixes
+	return nil
+}
+
+// numTyposParam parses a single number of typos into NumTypos and a
+// list of them into NumTyposByField.
+func numTyposParam(opts *SearchOptions, value string) error {
+	if !strings.Contains(value, ",") {
+		return intParam(func(opts *SearchOptions) **int { return &opts.NumTypos })(opts, value)
+	}
+	return intListParam(func(opts *SearchOptions) *[]int { return &opts.NumTyposByField })(opts, value)
+}
+
+func filterByParam(opts *SearchOptions, value string) error {
+	opts.FilterBy = []string{value}
+	return nil
+}
+
+func vectorQueryParam(opts *SearchOptions, value string) error {
+	vectorQuery, err := parseVectorQuery(value)
+	if err != nil {
+		return err
+	}
+	opts.VectorQuery = vectorQuery
+	return nil
+}
+
+// splitParamList splits a comma separated list, commas inside
+// parentheses, brackets or backticks do not split it, so sort_by
+// values such as _eval(tags:[a,b]):desc are kept whole.
+func splitParamList(value string) []string {
+	var values []string
+	depth, quoted, start := 0, false, 0
+	for i, r := range value {
+		switch {
+		case r == '`':
+			quoted = !quoted
+		case quoted:
+		case r == '(' || r == '[':
+			depth++
+		case r == ')' || r == ']':
+			depth--
+		case r == ',' && depth == 0:
+			values = append(values, strings.TrimSpace(value[start:i]))
+			start = i + 1
+		}
+	}
+	return append(values, strings.TrimSpace(value[start:]))
+}
+
+// parseVectorQuery parses a query in the vector_query syntax written
+// by VectorQuery.String.
+func parseVectorQuery(value string) (*VectorQuery, error) {
+	invalid := fmt.Errorf("value must be a vector query such as field:([0.1,0.2], k: 10)")
+	open := strings.Index(value, ":(")
+	if open <= 0 || !strings.HasSuffix(value, ")") {
+		return nil, invalid
+	}
+	vectorQuery := VectorQuery{Field: value[:open]}
+	params := splitParamList(value[open+2 : len(value)-1])
+	vector := params[0]
+	if !strings.HasPrefix(vector, "[") || !strings.HasSuffix(vector, "]") {
+		return nil, invalid
+	}
+	if vector = strings.TrimSpace(vector[1 : len(vector)-1]); vector != "" {
+		for _, component := range strings.Split(vector, ",") {
+			f, err := strconv.ParseFloat(strings.TrimSpace(component), 64)
+			if err != nil {
+				return nil, invalid
+			}
+			vectorQuery.Vector = append(vectorQuery.Vector, f)
+		}
+	}
+	for _, param := range params[1:] {
+		colon := strings.Index(param, ":")
+		if colon < 0 {
+			return nil, invalid
+		}
+		key, v := strings.TrimSpace(param[:colon]), strings.TrimSpace(param[colon+1:])
+		var err error
+		switch key {
+		case "id":
+			vectorQuery.ID = v
+		case "k":
+			vectorQuery.K, err = strconv.Atoi(v)
+		case "distance_threshold":
+			var f float64
+			f, err = strconv.ParseFloat(v, 64)
+			vectorQuery.DistanceThreshold = &f
+		case "alpha":
+			var f float64
+			f, err = strconv.ParseFloat(v, 64)
+			vectorQuery.Alpha = &f
+		default:
+			return nil, fmt.Errorf("unknown vector query parameter %s", key)
+		}
+		if err != nil {
+			return nil, invalid
+		}
+	}
+	return &vectorQuery, nil
+}
@@ -0,0 +1,159 @@
+package typesense
+
+import (
+	"net/url"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// fullSearchOptions returns search options with every option that can
+// be parsed back set.
+func fullSearchOptions() *SearchOptions {
+	number := 2
+	prefix := false
+	facetQuery := "tags:sci"
+	startTag, endTag := "<em>", "</em>"
+	threshold, alpha := 0.4, 0.7
+	enabled := true
+	splitJoinTokens, textMatchType := SplitJoinTokensFallback, TextMatchTypeMaxWeight
+	return &SearchOptions{
+		Query:                   "harry, potter",
+		QueryBy:                 []string{"title", "description"},
+		MaxHits:                 &number,
+		Prefix:                  &prefix,
+		FilterBy:                []string{"tags:=[`a,b`,c] && year:>2000"},
+		SortBy:                  []string{"_eval(tags:[a,b]):desc", "location(48.8, 2.3, exclude_radius: 2km):asc", "rating:desc"},
+		FacetBy:                 []string{"tags"},
+		MaxFacetValues:          &number,
+		FacetQuery:              &facetQuery,
+		NumTypos:                &number,
+		Page:                    &number,
+		PerPage:                 &number,
+		GroupBy:                 []string{"authors"},
+		GroupLimit:              &number,
+		IncludeFields:           []string{"title"},
+		ExcludeFields:           []string{"description"},
+		HighlightFullFields:     []string{"title"},
+		HighlightStartTag:       &startTag,
+		HighlightEndTag:         &endTag,
+		SnippetThreshold:        &number,
+		DropTokensThreshold:     &number,
+		TypoTokensThreshold:     &number,
+		PinnedHits:              []string{"1:1", "2:2"},
+		Hiddenhits:              []string{"3"},
+		QueryByWeights:          []int{2, 1},
+		Infix:                   []string{InfixFallback, InfixOff},
+		PrioritizeExactMatch:    &enabled,
+		PrioritizeTokenPosition: &enabled,
+		ExhaustiveSearch:        &enabled,
+		SearchCutoffMs:          &number,
+		EnableOverrides:         &enabled,
+		UseCache:                &enabled,
+		CacheTTL:                &number,
+		HighlightFields:         []string{"title"},
+		MinLen1Typo:             &number,
+		MinLen2Typo:             &number,
+		SplitJoinTokens:         &splitJoinTokens,
+		TextMatchType:           &textMatchType,
+		FacetSamplePercent:      &number,
+		MaxCandidates:           &number,
+		Preset:                  "listing",
+		VectorQuery: &VectorQuery{
+			Field:             "embedding",
+			Vector:            []float64{0.1, -0.2},
+			ID:                "7",
+			K:                 10,
+			DistanceThreshold: &threshold,
+			Alpha:             &alpha,
+		},
+	}
+}
+
+func TestParseSearchOptions(t *testing.T) {
+	opts := fullSearchOptions()
+	params, err := opts.encodeValues()
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	parsed, err := ParseSearchOptions(params)
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if !reflect.DeepEqual(parsed, opts) {
+		t.Errorf("Expected to parse %+v, received %+v", opts, parsed)
+	}
+}
+
+func TestParseSearchOptions_byField(t *testing.T) {
+	opts := &SearchOptions{
+		Query:           "harry",
+		QueryBy:         []string{"title", "description"},
+		PrefixByField:   []bool{true, false},
+		NumTyposByField: []int{2, 0},
+	}
+	params, err := opts.encodeValues()
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	parsed, err := ParseSearchOptions(params)
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if !reflect.DeepEqual(parsed, opts) {
+		t.Errorf("Expected to parse %+v, received %+v", opts, parsed)
+	}
+}
+
+func TestParseSearchOptions_everyParameter(t *testing.T) {
+	params, err := fullSearchOptions().encodeValues()
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	var encoded, parsed []string
+	for name := range params {
+		encoded = append(encoded, name)
+	}
+	for name := range searchParamParsers {
+		parsed = append(parsed, name)
+	}
+	sort.Strings(encoded)
+	sort.Strings(parsed)
+	if !reflect.DeepEqual(encoded, parsed) {
+		t.Errorf("Expected the parsed parameters %v to be the encoded parameters %v", parsed, encoded)
+	}
+}
+
+func TestParseSearchOptions_invalid(t *testing.T) {
+	params := url.Values{
+		"q":            {"harry"},
+		"query_by":     {"title"},
+		"page":         {"two"},
+		"prefix":       {"1"},
+		"per_page":     {"10", "20"},
+		"facet_by":     {""},
+		"sort_by":      {"a:asc,b:asc,c:asc,d:asc"},
+		"vector_query": {"embedding:([a])"},
+		"token":        {"secret"},
+		"callback":     {"x"},
+	}
+	opts, err := ParseSearchOptions(params)
+	paramsErr, ok := err.(*SearchParamsError)
+	if !ok {
+		t.Fatalf("Expected to receive a *SearchParamsError, received %v", err)
+	}
+	if expected := []string{"callback", "token"}; !reflect.DeepEqual(paramsErr.Unknown, expected) {
+		t.Errorf("Expected unknown parameters %v, received %v", expected, paramsErr.Unknown)
+	}
+	var invalid []string
+	for _, e := range paramsErr.Invalid {
+		invalid = append(invalid, e.Path)
+	}
+	expected := []string{"facet_by", "page", "per_page", "prefix", "vector_query", "sort_by"}
+	if !reflect.DeepEqual(invalid, expected) {
+		t.Errorf("Expected invalid parameters %v, received %v", expected, paramsErr.Invalid)
+	}
+	if opts == nil || opts.Query != "harry" || opts.QueryBy[0] != "title" || opts.Page != nil || opts.SortBy != nil {
+		t.Errorf("Expected the valid parameters to be parsed, received %+v", opts)
+	}
+}
@@ -0,0 +1,213 @@
+package typesense
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ValidateSearchOptions validates the search options against the
+// collection schema before the search is sent to Typesense. It checks
+// that query by fields are string fields, that facet by and group by
+// fields are facet fields, that sort by fields are sortable, that the
+// per field lists have one value per query by field and that the
+// vector query uses a float[] field with the dimensions of the vector.
+// It returns ValidationErrors with every violation found, the paths are
+// the names of the parameters, e.g. facet_by[1].
+func ValidateSearchOptions(schema CollectionSchema, searchOptions *SearchOptions) error {
+	var errs ValidationErrors
+	for i, name := range searchOptions.QueryBy {
+		path := fmt.Sprintf("query_by[%d]", i)
+		field, ok := searchSchemaField(schema, name)
+		if !ok {
+			errs = append(errs, ValidationError{Path: path, Message: "field " + name + " does not exist"})
+			continue
+		}
+		switch field.Type {
+		case "string", "string[]", "string*", "auto":
+		default:
+			errs = append(errs, ValidationError{Path: path, Message: "field " + name + " of type " + field.Type + " is not a string field"})
+		}
+	}
+	perField := []struct {
+		param string
+		len   int
+	}{
+		{"query_by_weights", len(searchOptions.QueryByWeights)},
+		{"prefix", len(searchOptions.PrefixByField)},
+		{"num_typos", len(searchOptions.NumTyposByField)},
+		{"infix", len(searchOptions.Infix)},
+	}
+	for _, list := range perField {
+		if list.len > 0 && len(searchOptions.QueryBy) > 0 && list.len != len(searchOptions.QueryBy) {
+			errs = append(errs, ValidationError{
+				Path:    list.param,
+				Message: fmt.Sprintf("must have %d values, one per query_by field, has %d", len(searchOptions.QueryBy), list.len),
+			})
+		}
+	}
+	errs = append(errs, validateFacetFields(schema, "facet_by", searchOptions.FacetBy)...)
+	errs = append(errs, validateFacetFields(schema, "group_by", searchOptions.GroupBy)...)
+
+	sortFields := make([]SortField, 0, len(searchOptions.SortBy)+len(searchOptions.Sort))
+	for _, sortBy := range searchOptions.SortBy {
+		sortFields = append(sortFields, parseSortField(sortBy))
+	}
+	sortFields = append(sortFields, searchOptions.Sort...)
+	if err := ValidateSortFields(schema, sortFields...); err != nil {
+		errs = append(errs, err.(ValidationErrors)...)
+	}
+
+	if vectorQuery := searchOptions.VectorQuery; vectorQuery != nil {
+		field, ok := searchSchemaField(schema, vectorQuery.Field)
+		switch {
+		case !ok:
+			errs = append(errs, ValidationError{Path: "vector_query", Message: "field " + vectorQuery.Field + " does not exist"})
+		case field.Type != "float[]" || field.NumDim == 0:
+			errs = append(errs, ValidationError{Path: "vector_query", Message: "field " + vectorQuery.Field + " is not a vector field"})
+		case len(vectorQuery.Vector) > 0 && len(vectorQuery.Vector) != field.NumDim:
+			errs = append(errs, ValidationError{
+				Path:    "vector_query",
+				Message: fmt.Sprintf("vector must have %d dimensions, has %d", field.NumDim, len(vectorQuery.Vector)),
+			})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// EnableSearchValidation validates the options of every search made by
+// the client with ValidateSearchOptions before it is sent. The schema
+// of the searched collection is retrieved on its first search and
+// cached, aliases are resolved to the schema of their collection and
+// the resolution is cached too. Invalid searches are not sent,
+// ValidationErrors is returned instead. The cached schemas are only
+// dropped by DeleteCollection, ForgetSearchSchema must be called when
+// a collection is changed or recreated or an alias is repointed
+// otherwise, such as by another process.
+func (c *Client) EnableSearchValidation() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.validateSearches = true
+	c.searchSchemas = make(map[string]CollectionSchema)
+}
+
+// DisableSearchValidation stops validating the searches and drops the
+// cached schemas.
+func (c *Client) DisableSearchValidation() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.validateSearches = false
+	c.searchSchemas = nil
+}
+
+// validateSearch validates the search options if search validation is
+// enabled.
+func (c *Client) validateSearch(collectionName string, searchOptions *SearchOptions) error {
+	c.mu.RLock()
+	enabled := c.validateSearches
+	schema, ok := c.searchSchemas[collectionName]
+	c.mu.RUnlock()
+	if !enabled {
+		return nil
+	}
+	if !ok {
+		var err error
+		if schema, err = c.retrieveSearchSchema(collectionName); err != nil {
+			return err
+		}
+		c.mu.Lock()
+		if c.searchSchemas != nil {
+			c.searchSchemas[collectionName] = schema
+		}
+		c.mu.Unlock()
+	}
+	return ValidateSearchOptions(schema, searchOptions)
+}
+
+// retrieveSearchSchema retrieves the schema of the collection, or of
+// the collection of the alias with the given name.
+func (c *Client) retrieveSearchSchema(name string) (CollectionSchema, error) {
+	collectionName := name
+	alias, err := c.RetrieveAlias(name)
+	if err == nil {
+		collectionName = alias.CollectionName
+	} else if httpErr, ok := err.(HTTPError); !ok || httpErr.Status != http.StatusNotFound {
+		return CollectionSchema{}, err
+	}
+	collection, err := c.RetrieveCollection(collectionName)
+	if err != nil {
+		return CollectionSchema{}, err
+	}
+	return collection.CollectionSchema, nil
+}
+
+// ForgetSearchSchema drops the cached schema of the collection or
+// alias with the given name, and of the aliases of the collection, so
+// it is retrieved again on the next search.
+func (c *Client) ForgetSearchSchema(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for searchName, schema := range c.searchSchemas {
+		if searchName == name || schema.Name == name {
+			delete(c.searchSchemas, searchName)
+		}
+	}
+}
+
+func validateFacetFields(schema CollectionSchema, param string, names []string) []ValidationError {
+	var errs []ValidationError
+	for i, name := range names {
+		path := fmt.Sprintf("%s[%d]", param, i)
+		field, ok := searchSchemaField(schema, name)
+		if !ok {
+			errs = append(errs, ValidationError{Path: path, Message: "field " + name + " does not exist"})
+		} else if !field.Facet {
+			errs = append(errs, ValidationError{Path: path, Message: "field " + name + " is not a facet field"})
+		}
+	}
+	return errs
+}
+
+// searchSchemaField returns the field of the schema with the given
+// name, or the wildcard field, such as .* or tags_.*, matching it.
+func searchSchemaField(schema CollectionSchema, name string) (CollectionField, bool) {
+	if field, ok := schemaField(schema, name); ok {
+		return field, true
+	}
+	for _, field := range schema.Fields {
+		if prefix := strings.TrimSuffix(field.Name, ".*"); prefix != field.Name && strings.HasPrefix(name, prefix) {
+			return field, true
+		}
+	}
+	return CollectionField{}, false
+}
+
+// parseSortField parses a sort_by value enough to validate it, the
+// coordinates of geo distance sorts are not parsed.
+func parseSortField(sortBy string) SortField {
+	var sortField SortField
+	expression := sortBy
+	if colon := strings.LastIndex(sortBy, ":"); colon >= 0 && !strings.Contains(sortBy[colon:], ")") {
+		expression, sortField.order = sortBy[:colon], strings.TrimSpace(sortBy[colon+1:])
+	}
+	open := strings.Index(expression, "(")
+	if open < 0 || !strings.HasSuffix(expression, ")") {
+		sortField.field = strings.TrimSpace(expression)
+		return sortField
+	}
+	sortField.field = strings.TrimSpace(expression[:open])
+	params := expression[open+1 : len(expression)-1]
+	switch {
+	case sortField.field == "_eval":
+		sortField.field = ""
+		sortField.eval = Raw(params)
+	case strings.HasPrefix(strings.TrimSpace(params), "missing_values:"):
+		sortField.missingValues = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(params), "missing_values:"))
+	default:
+		sortField.geo = &geoSort{}
+	}
+	return sortField
+}
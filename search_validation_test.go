@@ -0,0 +1,167 @@
+package typesense
+
+import (
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testSearchSchema = CollectionSchema{
+	Name: "books",
+	Fields: []CollectionField{
+		{Name: "title", Type: "string"},
+		{Name: "authors", Type: "string[]", Facet: true},
+		{Name: "year", Type: "int32", Facet: true},
+		{Name: "rating", Type: "float"},
+		{Name: "location", Type: "geopoint"},
+		{Name: "embedding", Type: "float[]", NumDim: 3},
+		{Name: "attr_.*", Type: "string"},
+	},
+	DefaultSortingField: "rating",
+}
+
+func TestValidateSearchOptions(t *testing.T) {
+	opts := &SearchOptions{
+		Query:          "harry",
+		QueryBy:        []string{"title", "authors", "attr_color"},
+		QueryByWeights: []int{3, 2, 1},
+		FacetBy:        []string{"authors", "year"},
+		GroupBy:        []string{"authors"},
+		SortBy:         []string{"_eval(year:>2000):desc", "location(48.85, 2.35, exclude_radius: 2km):asc"},
+		Sort:           []SortField{Desc("rating")},
+		VectorQuery:    &VectorQuery{Field: "embedding", Vector: []float64{1, 2, 3}},
+	}
+	if err := ValidateSearchOptions(testSearchSchema, opts); err != nil {
+		t.Errorf("Expected to receive no errors, received %v", err)
+	}
+}
+
+func TestValidateSearchOptions_invalid(t *testing.T) {
+	opts := &SearchOptions{
+		Query:          "harry",
+		QueryBy:        []string{"title", "year", "missing"},
+		QueryByWeights: []int{1, 2},
+		FacetBy:        []string{"authors", "title"},
+		GroupBy:        []string{"rating"},
+		SortBy:         []string{"title:asc", "year(missing_values: last):desc"},
+		Sort:           []SortField{GeoDistance("rating", 1, 2, SortAsc)},
+		VectorQuery:    &VectorQuery{Field: "embedding", Vector: []float64{1, 2}},
+	}
+	err := ValidateSearchOptions(testSearchSchema, opts)
+	expected := ValidationErrors{
+		{Path: "query_by[1]", Message: "field year of type int32 is not a string field"},
+		{Path: "query_by[2]", Message: "field missing does not exist"},
+		{Path: "query_by_weights", Message: "must have 3 values, one per query_by field, has 2"},
+		{Path: "facet_by[1]", Message: "field title is not a facet field"},
+		{Path: "group_by[0]", Message: "field rating is not a facet field"},
+		{Path: "sort_by[0]", Message: "field title of type string is not sortable"},
+		{Path: "sort_by[2]", Message: "field rating is not a geopoint"},
+		{Path: "vector_query", Message: "vector must have 3 dimensions, has 2"},
+	}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("Expected to receive error %v, received %v", expected, err)
+	}
+}
+
+func TestEnableSearchValidation(t *testing.T) {
+	requests := map[string]int{}
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		requests[req.URL.Path]++
+		switch req.URL.Path {
+		case "/aliases/library":
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(`{"name": "library", "collection_name": "books_v2"}`)),
+			}, nil
+		case "/aliases/books_v2":
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       ioutil.NopCloser(strings.NewReader(`{"message": "Not Found"}`)),
+			}, nil
+		case "/collections/books_v2":
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body: ioutil.NopCloser(strings.NewReader(
+					`{"name": "books_v2", "fields": [{"name": "title", "type": "string"}, {"name": "year", "type": "int32"}]}`,
+				)),
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(searchResultTest)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	client.EnableSearchValidation()
+	_, err := client.Search("library", "", nil, &SearchOptions{Query: "harry", QueryBy: []string{"year"}})
+	expected := ValidationErrors{{Path: "query_by[0]", Message: "field year of type int32 is not a string field"}}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("Expected to receive error %v, received %v", expected, err)
+	}
+	if _, err := client.Search("library", "harry", []string{"title"}, nil); err != nil {
+		t.Errorf("Expected to receive no errors, received %v", err)
+	}
+	if _, err := client.Search("books_v2", "harry", []string{"title"}, nil); err != nil {
+		t.Errorf("Expected to receive no errors, received %v", err)
+	}
+	if requests["/collections/books_v2"] != 2 || requests["/aliases/library"] != 1 {
+		t.Errorf("Expected the schemas to be retrieved once per name, received %v", requests)
+	}
+	if requests["/collections/library/documents/search"] != 1 {
+		t.Errorf("Expected only the valid search to be sent, received %v", requests)
+	}
+
+	_, err = client.MultiSearch(nil, []MultiSearchRequest{
+		{Collection: "library", Options: &SearchOptions{Query: "harry", QueryBy: []string{"title"}}},
+		{Collection: "library", Options: &SearchOptions{Query: "harry", FacetBy: []string{"title"}}},
+	})
+	expected = ValidationErrors{{Path: "searches[1].facet_by[0]", Message: "field title is not a facet field"}}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("Expected to receive error %v, received %v", expected, err)
+	}
+}
+
+func TestForgetSearchSchema(t *testing.T) {
+	requests := map[string]int{}
+	fields := `[{"name": "title", "type": "int32"}]`
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		requests[req.URL.Path]++
+		switch req.URL.Path {
+		case "/aliases/library":
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(`{"name": "library", "collection_name": "books_v2"}`)),
+			}, nil
+		case "/collections/books_v2":
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(`{"name": "books_v2", "fields": ` + fields + `}`)),
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(searchResultTest)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	client.EnableSearchValidation()
+	if _, err := client.Search("library", "harry", []string{"title"}, nil); err == nil {
+		t.Errorf("Expected to receive an error for the int32 field")
+	}
+	fields = `[{"name": "title", "type": "string"}]`
+	client.ForgetSearchSchema("books_v2")
+	if _, err := client.Search("library", "harry", []string{"title"}, nil); err != nil {
+		t.Errorf("Expected to receive no errors, received %v", err)
+	}
+	if requests["/collections/books_v2"] != 2 || requests["/aliases/library"] != 2 {
+		t.Errorf("Expected the schema to be retrieved again, received %v", requests)
+	}
+}
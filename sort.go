@@ -0,0 +1,208 @@
+package typesense
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// SortAsc sorts in ascending order.
+	SortAsc = "asc"
+
+	// SortDesc sorts in descending order.
+	SortDesc = "desc"
+
+	// MissingValuesFirst sorts documents without a value for the field
+	// before the other documents.
+	MissingValuesFirst = "first"
+
+	// MissingValuesLast sorts documents without a value for the field
+	// after the other documents.
+	MissingValuesLast = "last"
+
+	// SortTextMatch is the special sort field for the text match score.
+	SortTextMatch = "_text_match"
+
+	// SortSeqID is the special sort field for the document insertion
+	// order.
+	SortSeqID = "_seq_id"
+
+	// maxSortFields is the maximum number of sort fields accepted by
+	// Typesense.
+	maxSortFields = 3
+)
+
+// SortField is a sort_by field built with Asc, Desc, TextMatch, SeqID,
+// Eval or GeoDistance.
+type SortField struct {
+	field         string
+	order         string
+	eval          Filter
+	geo           *geoSort
+	missingValues string
+}
+
+type geoSort struct {
+	lat, lng      float64
+	excludeRadius string
+	precision     string
+}
+
+// Asc sorts by the field in ascending order.
+func Asc(field string) SortField {
+	return SortField{field: field, order: SortAsc}
+}
+
+// Desc sorts by the field in descending order.
+func Desc(field string) SortField {
+	return SortField{field: field, order: SortDesc}
+}
+
+// TextMatch sorts by the text match score in the given order.
+func TextMatch(order string) SortField {
+	return SortField{field: SortTextMatch, order: order}
+}
+
+// SeqID sorts by the document insertion order in the given order.
+func SeqID(order string) SortField {
+	return SortField{field: SortSeqID, order: order}
+}
+
+// Eval sorts documents that match the filter before, for descending
+// order, or after, for ascending order, the documents that do not.
+func Eval(filter Filter, order string) SortField {
+	return SortField{eval: filter, order: order}
+}
+
+// GeoDistance sorts by the distance between the geopoint field and the
+// point at lat and lng, in the given order.
+func GeoDistance(field string, lat, lng float64, order string) SortField {
+	return SortField{field: field, order: order, geo: &geoSort{lat: lat, lng: lng}}
+}
+
+// ExcludeRadius makes documents within radius of a GeoDistance point
+// be sorted as if they were at the same distance. Unit is the unit of
+// radius, km or mi.
+func (s SortField) ExcludeRadius(radius float64, unit string) SortField {
+	if s.geo != nil {
+		geo := *s.geo
+		geo.excludeRadius = formatFilterFloat(radius) + unit
+		s.geo = &geo
+	}
+	return s
+}
+
+// Precision groups GeoDistance distances into buckets of the given
+// size, documents in the same bucket are sorted by the next sort
+// field. Unit is the unit of precision, km or mi.
+func (s SortField) Precision(precision float64, unit string) SortField {
+	if s.geo != nil {
+		geo := *s.geo
+		geo.precision = formatFilterFloat(precision) + unit
+		s.geo = &geo
+	}
+	return s
+}
+
+// MissingValues sets where documents without a value for the field are
+// sorted, MissingValuesFirst or MissingValuesLast.
+func (s SortField) MissingValues(missingValues string) SortField {
+	s.missingValues = missingValues
+	return s
+}
+
+// Field returns the name of the sorted field, it is empty for Eval.
+func (s SortField) Field() string {
+	return s.field
+}
+
+// String returns the sort field in the Typesense sort_by syntax.
+func (s SortField) String() string {
+	var field string
+	switch {
+	case s.eval != nil:
+		field = "_eval(" + s.eval.String() + ")"
+	case s.geo != nil:
+		params := []string{formatFilterFloat(s.geo.lat), formatFilterFloat(s.geo.lng)}
+		if s.geo.excludeRadius != "" {
+			params = append(params, "exclude_radius: "+s.geo.excludeRadius)
+		}
+		if s.geo.precision != "" {
+			params = append(params, "precision: "+s.geo.precision)
+		}
+		field = s.field + "(" + strings.Join(params, ", ") + ")"
+	case s.missingValues != "":
+		field = s.field + "(missing_values: " + s.missingValues + ")"
+	default:
+		field = s.field
+	}
+	if s.order == "" {
+		return field
+	}
+	return field + ":" + s.order
+}
+
+// ValidateSortFields validates the sort fields against the collection
+// schema. It checks that there are at most three sort fields, that
+// every sorted field exists and is sortable, that geo distance sorts
+// use geopoint fields and that orders and missing values are valid.
+func ValidateSortFields(schema CollectionSchema, fields ...SortField) error {
+	var errs ValidationErrors
+	if len(fields) > maxSortFields {
+		errs = append(errs, ValidationError{
+			Path:    "sort_by",
+			Message: fmt.Sprintf("at most %d sort fields are allowed", maxSortFields),
+		})
+	}
+	for i, sortField := range fields {
+		path := fmt.Sprintf("sort_by[%d]", i)
+		if sortField.order != SortAsc && sortField.order != SortDesc {
+			errs = append(errs, ValidationError{Path: path, Message: "order must be asc or desc"})
+		}
+		if sortField.missingValues != "" && sortField.missingValues != MissingValuesFirst && sortField.missingValues != MissingValuesLast {
+			errs = append(errs, ValidationError{Path: path, Message: "missing values must be first or last"})
+		}
+		if sortField.eval != nil || sortField.field == SortTextMatch || sortField.field == SortSeqID {
+			continue
+		}
+		field, ok := schemaField(schema, sortField.field)
+		if !ok {
+			errs = append(errs, ValidationError{Path: path, Message: "field " + sortField.field + " does not exist"})
+			continue
+		}
+		if message := sortFieldTypeError(field, sortField.geo != nil); message != "" {
+			errs = append(errs, ValidationError{Path: path, Message: message})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func sortFieldTypeError(field CollectionField, geo bool) string {
+	if geo {
+		if field.Type != "geopoint" {
+			return "field " + field.Name + " is not a geopoint"
+		}
+		return ""
+	}
+	switch field.Type {
+	case "int32", "int64", "float", "bool":
+		return ""
+	case "string":
+		if field.Sort {
+			return ""
+		}
+	}
+	return "field " + field.Name + " of type " + field.Type + " is not sortable"
+}
+
+func schemaField(schema CollectionSchema, name string) (CollectionField, bool) {
+	for _, field := range schema.Fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return CollectionField{}, false
+}
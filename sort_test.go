@@ -0,0 +1,86 @@
+package typesense
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestSortField_String(t *testing.T) {
+	tests := []struct {
+		sort     SortField
+		expected string
+	}{
+		{Asc("price"), "price:asc"},
+		{Desc("rating"), "rating:desc"},
+		{Desc("rating").MissingValues(MissingValuesLast), "rating(missing_values: last):desc"},
+		{TextMatch(SortDesc), "_text_match:desc"},
+		{SeqID(SortAsc), "_seq_id:asc"},
+		{Eval(Eq("in_stock", true), SortDesc), "_eval(in_stock:=true):desc"},
+		{GeoDistance("location", 48.853, 2.344, SortAsc), "location(48.853, 2.344):asc"},
+		{
+			GeoDistance("location", 48.853, 2.344, SortAsc).ExcludeRadius(2, "mi").Precision(500, "m"),
+			"location(48.853, 2.344, exclude_radius: 2mi, precision: 500m):asc",
+		},
+	}
+	for _, test := range tests {
+		if sort := test.sort.String(); sort != test.expected {
+			t.Errorf("Expected sort %q, received %q", test.expected, sort)
+		}
+	}
+}
+
+func TestSearchOptions_sort(t *testing.T) {
+	opts := SearchOptions{
+		Query:   "query",
+		QueryBy: []string{"name"},
+		SortBy:  []string{"age:desc"},
+		Sort:    []SortField{TextMatch(SortDesc), Asc("price")},
+	}
+	form, err := opts.encodeForm()
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	values, _ := url.ParseQuery(form)
+	expected := "age:desc,_text_match:desc,price:asc"
+	if sortBy := values.Get("sort_by"); sortBy != expected {
+		t.Errorf("Expected sort_by %q, received %q", expected, sortBy)
+	}
+	opts.Sort = append(opts.Sort, SeqID(SortAsc))
+	if _, err := opts.encodeForm(); err != ErrTooManySortFields {
+		t.Errorf("Expected to receive error %v, received %v", ErrTooManySortFields, err)
+	}
+}
+
+func TestValidateSortFields(t *testing.T) {
+	schema := CollectionSchema{
+		Name: "places",
+		Fields: []CollectionField{
+			{Name: "name", Type: "string"},
+			{Name: "title", Type: "string", Sort: true},
+			{Name: "rating", Type: "float"},
+			{Name: "location", Type: "geopoint"},
+		},
+	}
+	if err := ValidateSortFields(schema, Desc("rating"), Asc("title"), GeoDistance("location", 1, 2, SortAsc)); err != nil {
+		t.Errorf("Expected to receive no errors, received %v", err)
+	}
+	err := ValidateSortFields(
+		schema,
+		Asc("name"),
+		GeoDistance("rating", 1, 2, SortAsc),
+		Desc("unknown").MissingValues("middle"),
+		SortField{field: "rating", order: "up"},
+	)
+	expected := ValidationErrors{
+		{Path: "sort_by", Message: "at most 3 sort fields are allowed"},
+		{Path: "sort_by[0]", Message: "field name of type string is not sortable"},
+		{Path: "sort_by[1]", Message: "field rating is not a geopoint"},
+		{Path: "sort_by[2]", Message: "missing values must be first or last"},
+		{Path: "sort_by[2]", Message: "field unknown does not exist"},
+		{Path: "sort_by[3]", Message: "order must be asc or desc"},
+	}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("Expected to receive error %v, received %v", expected, err)
+	}
+}
@@ -0,0 +1,152 @@
+package typesense
+
+import "encoding/json"
+
+// TypedCollection is a handle to a collection whose documents are of
+// type T. Documents are encoded and decoded directly from and to T,
+// so there is no need to unmarshal document responses or to type
+// assert search hits.
+type TypedCollection[T any] struct {
+	client *Client
+	name   string
+}
+
+// TypedSearchResponse is a SearchResponse with hits decoded into
+// documents of type T.
+type TypedSearchResponse[T any] struct {
+	FacetCounts   []FacetCount              `json:"facet_counts"`
+	Found         int                       `json:"found"`
+	Hits          []TypedSearchResultHit[T] `json:"hits"`
+	FoundDocs     int                       `json:"found_docs,omitempty"`
+	GroupedHits   []TypedGroupedHit[T]      `json:"grouped_hits,omitempty"`
+	OutOf         int                       `json:"out_of"`
+	Page          int                       `json:"page"`
+	SearchTimeMs  int                       `json:"search_time_ms"`
+	RequestParams *SearchRequestParams      `json:"request_params,omitempty"`
+	SearchCutoff  bool                      `json:"search_cutoff,omitempty"`
+}
+
+// TypedGroupedHit is a GroupedHit with hits decoded into documents of
+// type T.
+type TypedGroupedHit[T any] struct {
+	GroupKey []interface{}             `json:"group_key"`
+	Found    int                       `json:"found"`
+	Hits     []TypedSearchResultHit[T] `json:"hits"`
+}
+
+// TypedSearchResultHit is a SearchResultHit with the document decoded
+// into type T.
+type TypedSearchResultHit[T any] struct {
+	Highlights        []SearchHighlight      `json:"highlights"`
+	Document          T                      `json:"document"`
+	Highlight         map[string]interface{} `json:"highlight,omitempty"`
+	TextMatch         int64                  `json:"text_match,omitempty"`
+	TextMatchInfo     *TextMatchInfo         `json:"text_match_info,omitempty"`
+	GeoDistanceMeters map[string]float64     `json:"geo_distance_meters,omitempty"`
+	VectorDistance    *float64               `json:"vector_distance,omitempty"`
+	HybridSearchInfo  *HybridSearchInfo      `json:"hybrid_search_info,omitempty"`
+}
+
+// NewTypedCollection creates a handle for the collection with the given
+// name whose documents are of type T.
+func NewTypedCollection[T any](client *Client, collectionName string) *TypedCollection[T] {
+	return &TypedCollection[T]{
+		client: client,
+		name:   collectionName,
+	}
+}
+
+// Name returns the name of the collection.
+func (tc *TypedCollection[T]) Name() string {
+	return tc.name
+}
+
+// Index indexes a new document in the collection and returns the
+// indexed document.
+func (tc *TypedCollection[T]) Index(document T) (T, error) {
+	return tc.decode(tc.client.IndexDocument(tc.name, document))
+}
+
+// Upsert indexes a new document in the collection or replaces the
+// document with the same id, returning the indexed document.
+func (tc *TypedCollection[T]) Upsert(document T) (T, error) {
+	return tc.decode(tc.client.UpsertDocument(tc.name, document))
+}
+
+// Get retrieves a document in the collection by its id.
+func (tc *TypedCollection[T]) Get(documentID string) (T, error) {
+	return tc.decode(tc.client.RetrieveDocument(tc.name, documentID))
+}
+
+// Delete deletes a document in the collection by its id and returns
+// the deleted document.
+func (tc *TypedCollection[T]) Delete(documentID string) (T, error) {
+	return tc.decode(tc.client.DeleteDocument(tc.name, documentID))
+}
+
+// Search searches the collection using searchOptions, which must have
+// the Query and QueryBy fields set.
+func (tc *TypedCollection[T]) Search(searchOptions *SearchOptions) (*TypedSearchResponse[T], error) {
+	if searchOptions == nil {
+		return nil, ErrQueryRequired
+	}
+	var rawResponse TypedSearchResponse[json.RawMessage]
+	if err := tc.client.search(tc.name, searchOptions, &rawResponse); err != nil {
+		return nil, err
+	}
+	hits, err := decodeTypedHits[T](tc.client.encoder, rawResponse.Hits)
+	if err != nil {
+		return nil, err
+	}
+	searchResponse := TypedSearchResponse[T]{
+		FacetCounts:   rawResponse.FacetCounts,
+		Found:         rawResponse.Found,
+		Hits:          hits,
+		FoundDocs:     rawResponse.FoundDocs,
+		OutOf:         rawResponse.OutOf,
+		Page:          rawResponse.Page,
+		SearchTimeMs:  rawResponse.SearchTimeMs,
+		RequestParams: rawResponse.RequestParams,
+		SearchCutoff:  rawResponse.SearchCutoff,
+	}
+	for _, group := range rawResponse.GroupedHits {
+		hits, err := decodeTypedHits[T](tc.client.encoder, group.Hits)
+		if err != nil {
+			return nil, err
+		}
+		searchResponse.GroupedHits = append(searchResponse.GroupedHits, TypedGroupedHit[T]{
+			GroupKey: group.GroupKey,
+			Found:    group.Found,
+			Hits:     hits,
+		})
+	}
+	return &searchResponse, nil
+}
+
+func (tc *TypedCollection[T]) decode(documentResponse *DocumentResponse) (T, error) {
+	var document T
+	if err := documentResponse.UnmarshalDocument(&document); err != nil {
+		var zero T
+		return zero, err
+	}
+	return document, nil
+}
+
+// decodeTypedHits decodes the raw documents of the hits into documents
+// of type T.
+func decodeTypedHits[T any](encoder *DocumentEncoder, rawHits []TypedSearchResultHit[json.RawMessage]) ([]TypedSearchResultHit[T], error) {
+	hits := make([]TypedSearchResultHit[T], len(rawHits))
+	for i := range rawHits {
+		hits[i].Highlights = rawHits[i].Highlights
+		hits[i].Highlight = rawHits[i].Highlight
+		hits[i].TextMatch = rawHits[i].TextMatch
+		hits[i].TextMatchInfo = rawHits[i].TextMatchInfo
+		hits[i].GeoDistanceMeters = rawHits[i].GeoDistanceMeters
+		hits[i].VectorDistance = rawHits[i].VectorDistance
+		hits[i].HybridSearchInfo = rawHits[i].HybridSearchInfo
+		if err := decodeDocument(encoder, rawHits[i].Document, &hits[i].Document); err != nil {
+			return nil, err
+		}
+	}
+	return hits, nil
+}
@@ -0,0 +1,148 @@
+package typesense
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestTypedCollection_Upsert(t *testing.T) {
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		if action := req.URL.Query().Get("action"); action != ImportActionUpsert {
+			t.Errorf("Expected action %q, received %q", ImportActionUpsert, action)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       req.Body,
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	collection := NewTypedCollection[testDocumentStruct](&client, collectionNameTest)
+	document, err := collection.Upsert(testDocument)
+	if err != nil {
+		t.Errorf("Expected to receive no errors, received %v", err)
+	}
+	if document != testDocument {
+		t.Errorf("Expected to receive %v, received %v", testDocument, document)
+	}
+}
+
+func TestTypedCollection_Get(t *testing.T) {
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		documentJSON, _ := json.Marshal(testDocument)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(documentJSON)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	collection := NewTypedCollection[testDocumentStruct](&client, collectionNameTest)
+	document, err := collection.Get(testDocument.Field1)
+	if err != nil {
+		t.Errorf("Expected to receive no errors, received %v", err)
+	}
+	if document != testDocument {
+		t.Errorf("Expected to receive %v, received %v", testDocument, document)
+	}
+}
+
+func TestTypedCollection_Get_collectionNotFound(t *testing.T) {
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"message": "collection not found"}`)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	collection := NewTypedCollection[testDocumentStruct](&client, collectionNameTest)
+	if _, err := collection.Get(testDocument.Field1); err != ErrCollectionNotFound {
+		t.Errorf("Expected to receive error %v, received %v", ErrCollectionNotFound, err)
+	}
+}
+
+func TestTypedCollection_Search(t *testing.T) {
+	type book struct {
+		ID              string  `json:"id"`
+		Title           string  `json:"title"`
+		AverageRating   float64 `json:"average_rating"`
+		PublicationYear int     `json:"publication_year"`
+	}
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(searchResultTest)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	collection := NewTypedCollection[book](&client, "books")
+	searchResp, err := collection.Search(&SearchOptions{
+		Query:   "harry potter",
+		QueryBy: []string{"title"},
+	})
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if len(searchResp.Hits) != 1 {
+		t.Fatalf("Expected to get %d hit, got %d", 1, len(searchResp.Hits))
+	}
+	expected := book{
+		ID:              "2",
+		Title:           "Harry Potter and the Philosopher's Stone",
+		AverageRating:   4.44,
+		PublicationYear: 1997,
+	}
+	if searchResp.Hits[0].Document != expected {
+		t.Errorf("Expected to receive %v, received %v", expected, searchResp.Hits[0].Document)
+	}
+}
+
+func TestTypedCollection_Search_grouped(t *testing.T) {
+	type book struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+	}
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		if groupBy := req.URL.Query().Get("group_by"); groupBy != "authors" {
+			t.Errorf("Expected group_by %q, received %q", "authors", groupBy)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(groupedSearchResultTest)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	collection := NewTypedCollection[book](&client, "books")
+	searchResp, err := collection.Search(&SearchOptions{
+		Query:   "*",
+		QueryBy: []string{"title"},
+		GroupBy: []string{"authors"},
+	})
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if searchResp.FoundDocs != 3 || len(searchResp.GroupedHits) != 2 {
+		t.Fatalf("Expected %d documents in %d groups, received %+v", 3, 2, searchResp)
+	}
+	expected := book{ID: "3", Title: "The Hunger Games"}
+	if hits := searchResp.GroupedHits[1].Hits; len(hits) != 1 || hits[0].Document != expected {
+		t.Errorf("Expected to receive %v, received %v", expected, hits)
+	}
+}
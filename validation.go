@@ -0,0 +1,232 @@
+package typesense
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ValidationError is a single violation found when validating a value
+// against a collection schema.
+type ValidationError struct {
+	// Path is the JSON path of the invalid value, e.g. $.tags[1].
+	Path string
+
+	// Message describes the violation.
+	Message string
+}
+
+// Error returns a string representation of the violation.
+func (e ValidationError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// ValidationErrors are all the violations found when validating a
+// value against a collection schema.
+type ValidationErrors []ValidationError
+
+// Error returns a string representation of every violation.
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// ValidateDocument validates the document against the collection schema
+// before it is sent to Typesense. It checks that every required field
+// is present, that values match the field types, including arrays,
+// geopoints, vector dimensions and the int32 range, that the default
+// sorting field is set and that the id is a non-empty string. It
+// returns ValidationErrors with every violation found. The document is
+// encoded with encoding/json, use the ValidateDocument method of the
+// client to encode it with the DocumentEncoder of the client.
+func ValidateDocument(schema CollectionSchema, document interface{}) error {
+	data, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+	return validateDocumentJSON(schema, data, false)
+}
+
+// ValidateDocument validates the document against the collection schema
+// as the ValidateDocument function does, encoding it as the client
+// would send it, with its DocumentEncoder if one is set.
+func (c *Client) ValidateDocument(schema CollectionSchema, document interface{}) error {
+	data, err := c.marshalDocument(document)
+	if err != nil {
+		return err
+	}
+	return validateDocumentJSON(schema, data, false)
+}
+
+// EnableDocumentValidation validates every document written by the
+// client to the collection named after the schema, including imports,
+// before it is sent. Invalid documents are not sent, they are reported
+// as errors or as failed import results. Documents imported with the
+// update action are partial, only their fields are validated.
+func (c *Client) EnableDocumentValidation(schema CollectionSchema) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.schemas == nil {
+		c.schemas = make(map[string]CollectionSchema)
+	}
+	c.schemas[schema.Name] = schema
+}
+
+// DisableDocumentValidation stops validating the documents written to
+// the collection.
+func (c *Client) DisableDocumentValidation(collectionName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.schemas, collectionName)
+}
+
+// validateDocument validates the encoded document written with the
+// action if validation is enabled for the collection.
+func (c *Client) validateDocument(collectionName, action string, data []byte) error {
+	c.mu.RLock()
+	schema, ok := c.schemas[collectionName]
+	c.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+	return validateDocumentJSON(schema, data, action == ImportActionUpdate)
+}
+
+// validateDocumentJSON validates the encoded document, a partial
+// document is not required to have the required fields and the default
+// sorting field.
+func validateDocumentJSON(schema CollectionSchema, data []byte, partial bool) error {
+	var document map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil || document == nil {
+		return ValidationErrors{{Path: "$", Message: "document must be a JSON object"}}
+	}
+	var errs ValidationErrors
+	if id, ok := document["id"]; ok {
+		if s, isString := id.(string); !isString || s == "" {
+			errs = append(errs, ValidationError{Path: "$.id", Message: "id must be a non-empty string"})
+		}
+	}
+	for _, field := range schema.Fields {
+		if strings.Contains(field.Name, ".*") {
+			continue
+		}
+		path := "$." + field.Name
+		value, ok := document[field.Name]
+		if !ok || value == nil {
+			if !partial && (!field.Optional || field.Name == schema.DefaultSortingField) {
+				errs = append(errs, ValidationError{Path: path, Message: "field is required"})
+			}
+			continue
+		}
+		errs = append(errs, validateFieldValue(path, field.Type, value)...)
+		if values, ok := value.([]interface{}); ok && field.NumDim > 0 && len(values) != field.NumDim {
+			errs = append(errs, ValidationError{
+				Path:    path,
+				Message: fmt.Sprintf("vector must have %d dimensions, has %d", field.NumDim, len(values)),
+			})
+		}
+	}
+	if _, ok := schemaField(schema, schema.DefaultSortingField); !partial && schema.DefaultSortingField != "" && !ok {
+		if _, ok := document[schema.DefaultSortingField]; !ok {
+			errs = append(errs, ValidationError{
+				Path:    "$." + schema.DefaultSortingField,
+				Message: "default sorting field is required",
+			})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validateFieldValue validates value against the Typesense field type,
+// unknown types are not validated.
+func validateFieldValue(path, fieldType string, value interface{}) []ValidationError {
+	if strings.HasSuffix(fieldType, "[]") {
+		values, ok := value.([]interface{})
+		if !ok {
+			return []ValidationError{{Path: path, Message: "value must be an array of " + strings.TrimSuffix(fieldType, "[]")}}
+		}
+		var errs []ValidationError
+		for i, v := range values {
+			errs = append(errs, validateFieldValue(fmt.Sprintf("%s[%d]", path, i), strings.TrimSuffix(fieldType, "[]"), v)...)
+		}
+		return errs
+	}
+	var message string
+	switch fieldType {
+	case "string":
+		if _, ok := value.(string); !ok {
+			message = "value must be a string"
+		}
+	case "bool":
+		if _, ok := value.(bool); !ok {
+			message = "value must be a bool"
+		}
+	case "float":
+		if _, ok := value.(json.Number); !ok {
+			message = "value must be a number"
+		}
+	case "int32":
+		message = validateInteger(value, math.MinInt32, math.MaxInt32, "int32")
+	case "int64":
+		message = validateInteger(value, math.MinInt64, math.MaxInt64, "int64")
+	case "geopoint":
+		message = validateGeoPoint(value)
+	}
+	if message != "" {
+		return []ValidationError{{Path: path, Message: message}}
+	}
+	return nil
+}
+
+func validateInteger(value interface{}, min, max int64, fieldType string) string {
+	number, ok := value.(json.Number)
+	if !ok {
+		return "value must be an integer"
+	}
+	n, err := number.Int64()
+	if err != nil {
+		if strings.ContainsAny(number.String(), ".eE") {
+			return "value must be an integer"
+		}
+		return "value is out of the " + fieldType + " range"
+	}
+	if n < min || n > max {
+		return "value is out of the " + fieldType + " range"
+	}
+	return ""
+}
+
+// validateGeoPoint validates a [lat, lng] pair.
+func validateGeoPoint(value interface{}) string {
+	pair, ok := value.([]interface{})
+	if !ok || len(pair) != 2 {
+		return "value must be a [lat, lng] pair"
+	}
+	lat, latErr := numberValue(pair[0])
+	lng, lngErr := numberValue(pair[1])
+	if latErr != nil || lngErr != nil {
+		return "value must be a [lat, lng] pair"
+	}
+	if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return "value is out of the geopoint range"
+	}
+	return ""
+}
+
+func numberValue(value interface{}) (float64, error) {
+	number, ok := value.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("value must be a number")
+	}
+	return number.Float64()
+}
@@ -0,0 +1,176 @@
+package typesense
+
+import (
+	"bufio"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testValidationSchema = CollectionSchema{
+	Name: "products",
+	Fields: []CollectionField{
+		{Name: "name", Type: "string"},
+		{Name: "tags", Type: "string[]"},
+		{Name: "stock", Type: "int32"},
+		{Name: "price", Type: "float"},
+		{Name: "description", Type: "string", Optional: true},
+	},
+	DefaultSortingField: "stock",
+}
+
+func TestValidateDocument(t *testing.T) {
+	document := map[string]interface{}{
+		"id":    "1",
+		"name":  "product",
+		"tags":  []string{"tag"},
+		"stock": 10,
+		"price": 2.5,
+	}
+	if err := ValidateDocument(testValidationSchema, document); err != nil {
+		t.Errorf("Expected to receive no errors, received %v", err)
+	}
+}
+
+func TestValidateDocument_invalid(t *testing.T) {
+	document := map[string]interface{}{
+		"id":          1,
+		"tags":        []interface{}{"tag", 2},
+		"stock":       int64(1) << 40,
+		"price":       "2.5",
+		"description": nil,
+	}
+	err := ValidateDocument(testValidationSchema, document)
+	expected := ValidationErrors{
+		{Path: "$.id", Message: "id must be a non-empty string"},
+		{Path: "$.name", Message: "field is required"},
+		{Path: "$.tags[1]", Message: "value must be a string"},
+		{Path: "$.stock", Message: "value is out of the int32 range"},
+		{Path: "$.price", Message: "value must be a number"},
+	}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("Expected to receive error %v, received %v", expected, err)
+	}
+}
+
+func TestValidateDocument_geopoint(t *testing.T) {
+	schema := CollectionSchema{
+		Name: "stores",
+		Fields: []CollectionField{
+			{Name: "location", Type: "geopoint"},
+			{Name: "branches", Type: "geopoint[]"},
+		},
+	}
+	document := map[string]interface{}{
+		"location": GeoPoint{Lat: 48.8566, Lng: 2.3522},
+		"branches": []interface{}{GeoPoint{Lat: 1, Lng: 2}, []float64{91, 0}, "here"},
+	}
+	err := ValidateDocument(schema, document)
+	expected := ValidationErrors{
+		{Path: "$.branches[1]", Message: "value is out of the geopoint range"},
+		{Path: "$.branches[2]", Message: "value must be a [lat, lng] pair"},
+	}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("Expected to receive error %v, received %v", expected, err)
+	}
+}
+
+func TestEnableDocumentValidation(t *testing.T) {
+	var sent int
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		var lines []string
+		scanner := bufio.NewScanner(req.Body)
+		for scanner.Scan() {
+			sent++
+			lines = append(lines, `{"success": true}`)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(strings.Join(lines, "\n"))),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	client.EnableDocumentValidation(testValidationSchema)
+	valid := map[string]interface{}{"name": "product", "tags": []string{}, "stock": 1, "price": 1}
+	invalid := map[string]interface{}{"name": "product"}
+	if documentResp := client.IndexDocument(testValidationSchema.Name, invalid); documentResp.Error == nil {
+		t.Errorf("Expected to receive a validation error")
+	}
+	report, err := client.ImportDocuments(testValidationSchema.Name, []interface{}{valid, invalid}, nil)
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if sent != 1 {
+		t.Errorf("Expected to send %d document, sent %d", 1, sent)
+	}
+	if report.NumImported != 1 || report.NumFailed != 1 || report.Results[1].Success {
+		t.Errorf("Expected the invalid document to fail, received %+v", report)
+	}
+	client.DisableDocumentValidation(testValidationSchema.Name)
+	if _, err := client.ImportDocuments(testValidationSchema.Name, []interface{}{invalid}, nil); err != nil || sent != 2 {
+		t.Errorf("Expected the document to be sent after disabling validation")
+	}
+}
+
+func TestEnableDocumentValidation_update(t *testing.T) {
+	var sent int
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		var lines []string
+		scanner := bufio.NewScanner(req.Body)
+		for scanner.Scan() {
+			sent++
+			lines = append(lines, `{"success": true}`)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(strings.Join(lines, "\n"))),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	client.EnableDocumentValidation(testValidationSchema)
+	partial := map[string]interface{}{"id": "1", "price": 3.5}
+	invalid := map[string]interface{}{"id": "2", "stock": "ten"}
+	report, err := client.ImportDocuments(testValidationSchema.Name, []interface{}{partial, invalid}, &ImportOptions{
+		Action: ImportActionUpdate,
+	})
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if sent != 1 || report.NumImported != 1 || !report.Results[0].Success {
+		t.Errorf("Expected the partial document to be sent, received %+v", report)
+	}
+	if report.NumFailed != 1 || !strings.Contains(report.Results[1].Error, "$.stock") {
+		t.Errorf("Expected the present fields of the partial document to be validated, received %+v", report.Results[1])
+	}
+}
+
+func TestClient_ValidateDocument(t *testing.T) {
+	schema := CollectionSchema{
+		Name: "events",
+		Fields: []CollectionField{
+			{Name: "created_at", Type: "int64"},
+		},
+	}
+	document := struct {
+		EventID   int64     `json:"event_id" typesense:"id"`
+		CreatedAt time.Time `json:"created_at"`
+	}{EventID: 7, CreatedAt: time.Unix(1600000000, 0).UTC()}
+	expected := ValidationErrors{{Path: "$.created_at", Message: "value must be an integer"}}
+	if err := ValidateDocument(schema, document); !reflect.DeepEqual(err, expected) {
+		t.Errorf("Expected to receive error %v, received %v", expected, err)
+	}
+	client := Client{}
+	client.SetDocumentEncoder(&DocumentEncoder{})
+	if err := client.ValidateDocument(schema, document); err != nil {
+		t.Errorf("Expected to receive no errors, received %v", err)
+	}
+}
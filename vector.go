@@ -0,0 +1,62 @@
+package typesense
+
+import (
+	"strconv"
+	"strings"
+)
+
+// VectorQuery is a nearest neighbour query over a float[] field with
+// num_dim dimensions. Combined with a text query it makes a hybrid
+// search, whose hits are ranked by the fusion of both scores.
+type VectorQuery struct {
+	// Field is the name of the float[] field to search.
+	Field string
+
+	// Vector is the vector to search the neighbours of.
+	Vector []float64
+
+	// ID is the id of a document whose vector is searched instead of
+	// Vector.
+	ID string
+
+	// K is the number of nearest neighbours to return. Default value
+	// is the per page of the search.
+	K int
+
+	// DistanceThreshold excludes the neighbours farther than it.
+	DistanceThreshold *float64
+
+	// Alpha is the weight of the vector search in the rank fusion of a
+	// hybrid search, between 0 and 1. Default value is 0.3.
+	Alpha *float64
+}
+
+// String returns the query in the Typesense vector_query syntax, such
+// as embedding:([0.1,0.2], k: 10).
+func (q VectorQuery) String() string {
+	values := make([]string, len(q.Vector))
+	for i, value := range q.Vector {
+		values[i] = formatFilterFloat(value)
+	}
+	params := []string{"[" + strings.Join(values, ",") + "]"}
+	if q.ID != "" {
+		params = append(params, "id: "+q.ID)
+	}
+	if q.K > 0 {
+		params = append(params, "k: "+strconv.Itoa(q.K))
+	}
+	if q.DistanceThreshold != nil {
+		params = append(params, "distance_threshold: "+formatFilterFloat(*q.DistanceThreshold))
+	}
+	if q.Alpha != nil {
+		params = append(params, "alpha: "+formatFilterFloat(*q.Alpha))
+	}
+	return q.Field + ":(" + strings.Join(params, ", ") + ")"
+}
+
+// HybridSearchInfo details the score of a hit of a hybrid search.
+type HybridSearchInfo struct {
+	// RankFusionScore is the fusion of the text match and the vector
+	// distance ranks the hits are sorted by.
+	RankFusionScore float64 `json:"rank_fusion_score"`
+}
@@ -0,0 +1,120 @@
+package typesense
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestVectorQuery_String(t *testing.T) {
+	threshold, alpha := 0.3, 0.8
+	tests := []struct {
+		query    VectorQuery
+		expected string
+	}{
+		{VectorQuery{Field: "embedding", Vector: []float64{0.1, -0.25}}, "embedding:([0.1,-0.25])"},
+		{VectorQuery{Field: "embedding", ID: "42", K: 10}, "embedding:([], id: 42, k: 10)"},
+		{
+			VectorQuery{Field: "embedding", Vector: []float64{1}, K: 5, DistanceThreshold: &threshold, Alpha: &alpha},
+			"embedding:([1], k: 5, distance_threshold: 0.3, alpha: 0.8)",
+		},
+	}
+	for _, test := range tests {
+		if query := test.query.String(); query != test.expected {
+			t.Errorf("Expected vector query %q, received %q", test.expected, query)
+		}
+	}
+}
+
+func TestSearch_vectorQuery(t *testing.T) {
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodPost || req.URL.Path != "/multi_search" {
+			t.Errorf("Expected POST /multi_search, received %s %s", req.Method, req.URL.Path)
+		}
+		if req.URL.RawQuery != "" {
+			t.Errorf("Expected no query string, received %q", req.URL.RawQuery)
+		}
+		var body struct {
+			Searches []map[string]string `json:"searches"`
+		}
+		json.NewDecoder(req.Body).Decode(&body)
+		if len(body.Searches) != 1 || body.Searches[0]["vector_query"] != "embedding:([0.1,0.2], k: 2)" ||
+			body.Searches[0]["collection"] != "books" {
+			t.Errorf("Expected the vector query in the body, received %v", body.Searches)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: ioutil.NopCloser(strings.NewReader(`{"results": [{
+				"facet_counts": [],
+				"found": 1,
+				"hits": [{
+					"highlights": [],
+					"document": {"id": "1"},
+					"vector_distance": 0.12,
+					"hybrid_search_info": {"rank_fusion_score": 0.75}
+				}]
+			}]}`)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	searchResp, err := client.Search("books", "harry", []string{"title"}, &SearchOptions{
+		Query:       "harry",
+		QueryBy:     []string{"title"},
+		VectorQuery: &VectorQuery{Field: "embedding", Vector: []float64{0.1, 0.2}, K: 2},
+	})
+	if err != nil {
+		t.Fatalf("Expected to receive no errors, received %v", err)
+	}
+	if len(searchResp.Hits) != 1 {
+		t.Fatalf("Expected %d hit, received %d", 1, len(searchResp.Hits))
+	}
+	hit := searchResp.Hits[0]
+	if hit.VectorDistance == nil || *hit.VectorDistance != 0.12 {
+		t.Errorf("Expected vector distance %v, received %v", 0.12, hit.VectorDistance)
+	}
+	if hit.HybridSearchInfo == nil || hit.HybridSearchInfo.RankFusionScore != 0.75 {
+		t.Errorf("Expected rank fusion score %v, received %+v", 0.75, hit.HybridSearchInfo)
+	}
+}
+
+func TestSearch_vectorQueryNotFound(t *testing.T) {
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"results": [{"code": 404, "error": "Not found."}]}`)),
+		}, nil
+	}
+	client := Client{
+		httpClient: mockClient,
+		masterNode: testMasterNode,
+	}
+	_, err := client.Search("books", "*", []string{"title"}, &SearchOptions{
+		Query:       "*",
+		QueryBy:     []string{"title"},
+		VectorQuery: &VectorQuery{Field: "embedding", ID: "1"},
+	})
+	if err != ErrNotFound {
+		t.Errorf("Expected to receive error %v, received %v", ErrNotFound, err)
+	}
+}
+
+func TestValidateDocument_vector(t *testing.T) {
+	schema := CollectionSchema{
+		Name:   "books",
+		Fields: []CollectionField{{Name: "embedding", Type: "float[]", NumDim: 3}},
+	}
+	if err := ValidateDocument(schema, map[string]interface{}{"embedding": []float64{1, 2, 3}}); err != nil {
+		t.Errorf("Expected to receive no errors, received %v", err)
+	}
+	err := ValidateDocument(schema, map[string]interface{}{"embedding": []float64{1, 2}})
+	expected := ValidationErrors{{Path: "$.embedding", Message: "vector must have 3 dimensions, has 2"}}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("Expected to receive error %v, received %v", expected, err)
+	}
+}